@@ -2,9 +2,6 @@ module github.com/nickwells/twrap.mod
 
 go 1.26.0
 
-require (
-	github.com/nickwells/mathutil.mod/v2 v2.5.11
-	github.com/nickwells/testhelper.mod/v2 v2.6.1
-)
+require github.com/nickwells/testhelper.mod/v2 v2.6.1
 
 require golang.org/x/exp v0.0.0-20260410095643-746e56fc9e2f // indirect
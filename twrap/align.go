@@ -0,0 +1,23 @@
+package twrap
+
+// Alignment describes how the wrapped text is placed between the indent
+// and the target line length
+type Alignment int
+
+// These are the available alignments. The default is AlignLeft which gives
+// ragged-right text.
+//
+// AlignJustify stretches the spaces between the words so that every line of
+// a paragraph except the last reaches the maximum line length; the last line
+// of each paragraph is left aligned.
+const (
+	AlignLeft Alignment = iota
+	AlignRight
+	AlignCentre
+	AlignJustify
+)
+
+// IsValid returns true if the Alignment is one of the known values
+func (a Alignment) IsValid() bool {
+	return a >= AlignLeft && a <= AlignJustify
+}
@@ -0,0 +1,80 @@
+package twrap_test
+
+import (
+	"testing"
+
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+	"github.com/nickwells/twrap.mod/twrap"
+)
+
+func TestAlign(t *testing.T) {
+	const text = "aaa bbb ccc ddd eee fff ggg hhh iii jjj\n" +
+		"- kkk lll mmm nnn ooo ppp"
+
+	testCases := []struct {
+		testhelper.ID
+		align    twrap.Alignment
+		expLines []string
+	}{
+		{
+			ID:    testhelper.MkID("left"),
+			align: twrap.AlignLeft,
+			expLines: []string{
+				"  aaa bbb ccc ddd",
+				"  eee fff ggg hhh",
+				"  iii jjj",
+				"  - kkk lll mmm nnn",
+				"    ooo ppp",
+			},
+		},
+		{
+			ID:    testhelper.MkID("right"),
+			align: twrap.AlignRight,
+			expLines: []string{
+				"     aaa bbb ccc ddd",
+				"     eee fff ggg hhh",
+				"             iii jjj",
+				"   - kkk lll mmm nnn",
+				"             ooo ppp",
+			},
+		},
+		{
+			ID:    testhelper.MkID("centre"),
+			align: twrap.AlignCentre,
+			expLines: []string{
+				"   aaa bbb ccc ddd",
+				"   eee fff ggg hhh",
+				"       iii jjj",
+				"  - kkk lll mmm nnn",
+				"        ooo ppp",
+			},
+		},
+		{
+			ID:    testhelper.MkID("justify"),
+			align: twrap.AlignJustify,
+			expLines: []string{
+				"  aaa  bbb  ccc  ddd",
+				"  eee  fff  ggg  hhh",
+				"  iii jjj",
+				"  - kkk  lll mmm nnn",
+				"    ooo ppp",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		twc := twrap.NewTWConfOrPanic(
+			twrap.SetMinChars(10),
+			twrap.SetTargetLineLen(20),
+			twrap.SetAlignment(tc.align))
+
+		testhelper.DiffStringSlice(t, tc.IDStr(), "wrapped lines",
+			twc.WrapLines(text, 2), tc.expLines)
+	}
+}
+
+func TestSetAlignment(t *testing.T) {
+	_, err := twrap.NewTWConf(twrap.SetAlignment(twrap.Alignment(99)))
+	testhelper.CheckError(t, "bad alignment", err,
+		true, []string{"bad alignment: 99"})
+}
@@ -0,0 +1,139 @@
+package twrap
+
+import "strings"
+
+const (
+	esc      = '\x1b'
+	sgrReset = "\x1b[0m"
+)
+
+// escSeqLen returns the length in bytes of the ANSI escape sequence at the
+// start of s. Only CSI (ESC '[' ...) and OSC (ESC ']' ...) sequences are
+// recognised; if s does not start with a complete sequence of either sort
+// then zero is returned.
+func escSeqLen(s string) int {
+	if len(s) < 2 || s[0] != esc {
+		return 0
+	}
+
+	switch s[1] {
+	case '[':
+		for i := 2; i < len(s); i++ {
+			c := s[i]
+			if c >= 0x40 && c <= 0x7e { // the final byte
+				return i + 1
+			}
+
+			if c < 0x20 || c > 0x3f { // not a parameter or intermediate byte
+				return 0
+			}
+		}
+	case ']':
+		for i := 2; i < len(s); i++ {
+			switch s[i] {
+			case '\a':
+				return i + 1
+			case esc:
+				if i+1 < len(s) && s[i+1] == '\\' {
+					return i + 2
+				}
+
+				return 0
+			}
+		}
+	}
+
+	return 0
+}
+
+// isPartialEscSeq returns true if s is the incomplete start of a CSI or OSC
+// escape sequence; that is, if more text could complete the sequence.
+func isPartialEscSeq(s string) bool {
+	if s == "" || s[0] != esc {
+		return false
+	}
+
+	if len(s) == 1 {
+		return true
+	}
+
+	switch s[1] {
+	case '[':
+		for i := 2; i < len(s); i++ {
+			if s[i] < 0x20 || s[i] > 0x3f {
+				return false
+			}
+		}
+
+		return true
+	case ']':
+		for i := 2; i < len(s); i++ {
+			switch s[i] {
+			case '\a':
+				return false
+			case esc:
+				return i == len(s)-1
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// isSGR returns true if the escape sequence is a CSI sequence setting the
+// graphic rendition (colour, weight, underlining etc.)
+func isSGR(seq string) bool {
+	return strings.HasPrefix(seq, "\x1b[") && strings.HasSuffix(seq, "m")
+}
+
+// sgrStyle records the SGR escape sequences that have been printed since the
+// style was last reset. These are the sequences needed to restore the
+// current style after it has been closed at the end of a line.
+type sgrStyle struct {
+	seqs []string
+}
+
+// update scans the text for SGR escape sequences and records their effect
+// on the style
+func (s *sgrStyle) update(text string) {
+	for i := 0; i < len(text); i++ {
+		n := escSeqLen(text[i:])
+		if n == 0 {
+			continue
+		}
+
+		seq := text[i : i+n]
+		i += n - 1
+
+		if !isSGR(seq) {
+			continue
+		}
+
+		params := seq[2 : len(seq)-1]
+
+		first, rest, hasRest := strings.Cut(params, ";")
+		if first == "" || strings.Trim(first, "0") == "" {
+			s.seqs = s.seqs[:0]
+
+			if hasRest {
+				s.seqs = append(s.seqs, "\x1b["+rest+"m")
+			}
+
+			continue
+		}
+
+		s.seqs = append(s.seqs, seq)
+	}
+}
+
+// isSet returns true if some style is in effect
+func (s sgrStyle) isSet() bool {
+	return len(s.seqs) > 0
+}
+
+// open returns the escape sequences needed to restore the style
+func (s sgrStyle) open() string {
+	return strings.Join(s.seqs, "")
+}
@@ -0,0 +1,131 @@
+package twrap_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+	"github.com/nickwells/twrap.mod/twrap"
+)
+
+func TestBlankLineParas(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		opts     []twrap.TWConfOptFunc
+		text     string
+		expLines []string
+	}{
+		{
+			ID: testhelper.MkID("prose"),
+			text: "\n\nThis is a paragraph\nwhich has been broken\ninto lines.\n" +
+				"\n \n\n" +
+				"This is another\r\nparagraph.\fAnd a third.\n",
+			expLines: []string{
+				"  This is a paragraph which",
+				"  has been broken into lines.",
+				"",
+				"  This is another paragraph.",
+				"",
+				"  And a third.",
+			},
+		},
+		{
+			ID:       testhelper.MkID("trailing blank lines"),
+			text:     "a short paragraph\n\n \n\n",
+			expLines: []string{"  a short paragraph"},
+		},
+		{
+			ID:   testhelper.MkID("blank line holding spaces"),
+			text: "one two\n\n \n  end of it",
+			expLines: []string{
+				"  one two",
+				"",
+				"    end of it",
+			},
+		},
+		{
+			ID: testhelper.MkID("list items"),
+			text: "- the first item which\n  goes on to wrap\n\n" +
+				"- the second item",
+			expLines: []string{
+				"  - the first item which",
+				"    goes on to wrap",
+				"",
+				"  - the second item",
+			},
+		},
+		{
+			ID: testhelper.MkID("verbatim"),
+			opts: []twrap.TWConfOptFunc{
+				twrap.SetVerbatimIndent(4),
+				twrap.SetVerbatimFence(twrap.DfltVerbatimFence),
+			},
+			text: "Run the\ncommand:\n\n" +
+				"    $ cmd -a\n    $ cmd -b\n\n" +
+				"```\nfunc f() {\n\n}\n```\n\n" +
+				"and that's\nall.",
+			expLines: []string{
+				"  Run the command:",
+				"",
+				"      $ cmd -a",
+				"      $ cmd -b",
+				"",
+				"  func f() {",
+				"",
+				"  }",
+				"",
+				"  and that's all.",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		opts := append([]twrap.TWConfOptFunc{
+			twrap.SetMinChars(10),
+			twrap.SetTargetLineLen(30),
+			twrap.SetBlankLineParas(true),
+		}, tc.opts...)
+		twc := twrap.NewTWConfOrPanic(opts...)
+
+		testhelper.DiffStringSlice(t, tc.IDStr(), "wrapped lines",
+			twc.WrapLines(tc.text, 2), tc.expLines)
+
+		var b strings.Builder
+
+		twc.W = &b
+		w := twrap.NewWriter(*twc, 2)
+
+		for _, c := range []byte(tc.text) {
+			_, _ = w.Write([]byte{c})
+		}
+
+		_ = w.Close()
+		testhelper.DiffString(t, tc.IDStr(), "Writer output",
+			b.String(), strings.Join(tc.expLines, "\n")+"\n")
+	}
+}
+
+func TestBlankLineParasLargeWriter(t *testing.T) {
+	// doc comment style text: long paragraphs with single newlines and
+	// blank lines holding white space
+	para := strings.Repeat("a line of prose in a long doc comment\n", 500)
+	text := strings.Repeat(para+"\n \n  ", 4)
+
+	twc := twrap.NewTWConfOrPanic(
+		twrap.SetTargetLineLen(60),
+		twrap.SetBlankLineParas(true))
+	exp := twc.Wrap3IndentString(text, 2, 3, 4)
+
+	var b strings.Builder
+
+	twc.W = &b
+	w := twrap.NewWriter(*twc, 2, 3, 4)
+
+	for _, c := range []byte(text) {
+		_, _ = w.Write([]byte{c})
+	}
+
+	_ = w.Close()
+
+	testhelper.DiffString(t, "large input", "Writer output", b.String(), exp)
+}
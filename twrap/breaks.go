@@ -0,0 +1,104 @@
+package twrap
+
+import "math"
+
+// BreakStrategy describes how the text of a paragraph is split into lines
+type BreakStrategy int
+
+// These are the available break strategies.
+//
+// BreakGreedy, the default, puts as many words as will fit onto each line
+// before starting the next. It is fast and the text can be printed as soon
+// as each line is complete but it can leave very ragged paragraphs,
+// especially when the lines are short.
+//
+// BreakOptimal chooses the line breaks so as to minimise the total
+// raggedness of the paragraph (the sum of the squares of the space left at
+// the end of each line apart from the last). The whole paragraph must be
+// collected before any of it can be printed and it takes longer to
+// calculate.
+const (
+	BreakGreedy BreakStrategy = iota
+	BreakOptimal
+)
+
+// IsValid returns true if the BreakStrategy is one of the known values
+func (bs BreakStrategy) IsValid() bool {
+	return bs >= BreakGreedy && bs <= BreakOptimal
+}
+
+// hyphenPenalty is added to the cost of any line ending with a hyphen so
+// that a line is only hyphenated if it significantly improves the layout
+const hyphenPenalty = 25
+
+// sqr returns the square of the value, or zero if it is negative
+func sqr(v int) int {
+	if v < 0 {
+		return 0
+	}
+
+	return v * v
+}
+
+// optimalBreaks returns the indexes of the words which should start a new
+// line so as to minimise the raggedness of the paragraph. The first line
+// has room for firstMax cells and the others have room for otherMax cells.
+// Each line will hold at least one word, however long, and any word which
+// must start a line will do so. A line may only end between words or
+// before a part of a word which is glued to the preceding part, in which
+// case the line ends with a hyphen. The leading spaces of the first word
+// of each line are only counted for the first line as they are discarded
+// when a word starts a later line.
+func optimalBreaks(words []lineWord, firstMax, otherMax int) []int {
+	n := len(words)
+	cost := make([]int, n+1) // the minimum cost of words[i:]
+	next := make([]int, n+1) // the start of the line after words[i]
+
+	for i := n - 1; i >= 0; i-- {
+		maxLen := otherMax
+		width := 0
+
+		if i == 0 {
+			maxLen = firstMax
+			width = words[0].spacesLen
+		}
+
+		cost[i] = math.MaxInt
+
+		for j := i; j < n; j++ {
+			if j > i {
+				width += words[j].spacesLen
+			}
+
+			width += words[j].wordLen
+
+			if j > i && (width > maxLen || words[j].mustStartLine) {
+				break
+			}
+
+			lineCost := 0
+			if j < n-1 && words[j+1].glued {
+				// the line ends with a hyphen
+				if width+len(hyphen) > maxLen && j > i {
+					break
+				}
+
+				lineCost = hyphenPenalty + sqr(maxLen-width-len(hyphen))
+			} else if j < n-1 && width < maxLen {
+				lineCost = sqr(maxLen - width)
+			}
+
+			if c := lineCost + cost[j+1]; c < cost[i] {
+				cost[i] = c
+				next[i] = j + 1
+			}
+		}
+	}
+
+	var starts []int
+	for i := next[0]; i < n; i = next[i] {
+		starts = append(starts, i)
+	}
+
+	return starts
+}
@@ -0,0 +1,128 @@
+package twrap_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+	"github.com/nickwells/twrap.mod/twrap"
+)
+
+func TestBreakStrategy(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		bs       twrap.BreakStrategy
+		lineLen  int
+		indent   int
+		text     string
+		expLines []string
+	}{
+		{
+			ID:      testhelper.MkID("greedy"),
+			bs:      twrap.BreakGreedy,
+			lineLen: 6,
+			text:    "aaa bb cc ddddd",
+			expLines: []string{
+				"aaa bb",
+				"cc",
+				"ddddd",
+			},
+		},
+		{
+			ID:      testhelper.MkID("optimal"),
+			bs:      twrap.BreakOptimal,
+			lineLen: 6,
+			text:    "aaa bb cc ddddd",
+			expLines: []string{
+				"aaa",
+				"bb cc",
+				"ddddd",
+			},
+		},
+		{
+			ID:      testhelper.MkID("optimal - long word"),
+			bs:      twrap.BreakOptimal,
+			lineLen: 6,
+			text:    "aa bb ccccccccc d e f",
+			expLines: []string{
+				"aa bb",
+				"ccccccccc",
+				"d e f",
+			},
+		},
+		{
+			ID:      testhelper.MkID("optimal - indent, list, paragraphs"),
+			bs:      twrap.BreakOptimal,
+			lineLen: 14,
+			indent:  2,
+			text:    "aaaaaaa bbbb cc ddddddddddd\n- aaaaa bbbb cc ddddddddd",
+			expLines: []string{
+				"  aaaaaaa",
+				"  bbbb cc",
+				"  ddddddddddd",
+				"  - aaaaa",
+				"    bbbb cc",
+				"    ddddddddd",
+			},
+		},
+		{
+			ID:      testhelper.MkID("greedy - indent, list, paragraphs"),
+			bs:      twrap.BreakGreedy,
+			lineLen: 14,
+			indent:  2,
+			text:    "aaaaaaa bbbb cc ddddddddddd\n- aaaaa bbbb cc ddddddddd",
+			expLines: []string{
+				"  aaaaaaa bbbb",
+				"  cc",
+				"  ddddddddddd",
+				"  - aaaaa bbbb",
+				"    cc",
+				"    ddddddddd",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		twc := twrap.NewTWConfOrPanic(
+			twrap.SetMinChars(1),
+			twrap.SetTargetLineLen(tc.lineLen),
+			twrap.SetBreakStrategy(tc.bs))
+
+		testhelper.DiffStringSlice(t, tc.IDStr(), "wrapped lines",
+			twc.WrapLines(tc.text, tc.indent), tc.expLines)
+	}
+}
+
+// benchmarkWrap wraps a long paragraph using the given break strategy
+func benchmarkWrap(b *testing.B, bs twrap.BreakStrategy, lineLen int) {
+	b.Helper()
+
+	text := strings.Repeat("The quality of mercy is not strained."+
+		" It droppeth as the gentle rain from heaven upon the place"+
+		" beneath. ", 200)
+	twc := twrap.NewTWConfOrPanic(
+		twrap.SetWriter(io.Discard),
+		twrap.SetTargetLineLen(lineLen),
+		twrap.SetBreakStrategy(bs))
+
+	for b.Loop() {
+		twc.Wrap(text, 0)
+	}
+}
+
+func BenchmarkWrapGreedy(b *testing.B) {
+	benchmarkWrap(b, twrap.BreakGreedy, twrap.DfltTargetLineLen)
+}
+
+func BenchmarkWrapOptimal(b *testing.B) {
+	benchmarkWrap(b, twrap.BreakOptimal, twrap.DfltTargetLineLen)
+}
+
+func BenchmarkWrapGreedyNarrow(b *testing.B) {
+	benchmarkWrap(b, twrap.BreakGreedy, twrap.DfltMinCharsToPrint)
+}
+
+func BenchmarkWrapOptimalNarrow(b *testing.B) {
+	benchmarkWrap(b, twrap.BreakOptimal, twrap.DfltMinCharsToPrint)
+}
@@ -0,0 +1,145 @@
+package twrap
+
+import "strings"
+
+// DfltGutter is the default number of spaces between columns
+const DfltGutter = 2
+
+// Columns will print the text in side-by-side columns, like a newspaper.
+// The text is wrapped to fit the width of a column, using the same rules
+// as Wrap, and the lines are shared between the columns so that they are
+// of equal height (apart from the last). The columns are separated by the
+// Gutter and the whole is indented by the given amount. The width of each
+// column is the space left after the indent and the gutters divided by the
+// number of columns, though it will never be less than one. A number of
+// columns less than one is taken as one.
+//
+// It returns the number of bytes written and any error encountered.
+func (twc TWConf) Columns(text string, cols, indent int) (int, error) {
+	return twc.captureLimited(indent, func(twc TWConf) {
+		cols = max(cols, 1)
+		width := max(
+			(twc.columnsWidth(indent)-twc.Gutter*(cols-1))/cols, 1)
+
+		colTWC := twc
+		colTWC.TargetLineLen = width
+		colTWC.MinCharsToPrint = min(twc.MinCharsToPrint, width)
+		colTWC.LineLeader = ""
+		colTWC.MaxLines = 0
+
+		lines := colTWC.WrapLines(text, 0)
+		rows := (len(lines) + cols - 1) / cols
+
+		columns := make([][]string, 0, cols)
+		for start := 0; start < len(lines); start += rows {
+			columns = append(columns, lines[start:min(start+rows, len(lines))])
+		}
+
+		twc.printColumns(columns, width, indent)
+	})
+}
+
+// ListColumns will print the list items packed into as many columns as
+// will fit, in the way that ls prints file names. The items are not
+// wrapped. They are printed down the first column and then down the next
+// and so on, each column being as wide as its widest item. The columns are
+// separated by the Gutter and the whole is indented by the given amount.
+// If the items will not fit in more than one column they are printed one
+// per line.
+//
+// It returns the number of bytes written and any error encountered.
+func (twc TWConf) ListColumns(list []string, indent int) (int, error) {
+	return twc.captureLimited(indent, func(twc TWConf) {
+		if len(list) == 0 {
+			return
+		}
+
+		widths := make([]int, len(list))
+		for i, li := range list {
+			widths[i] = strWidth(li)
+		}
+
+		maxLen := twc.columnsWidth(indent)
+
+		for cols := len(list); cols >= 1; cols-- {
+			rows := (len(list) + cols - 1) / cols
+			if cols > 1 && (len(list)+rows-1)/rows < cols {
+				continue // the last column(s) would be empty
+			}
+
+			var (
+				columns  [][]string
+				colWidth []int
+				total    = -twc.Gutter
+			)
+
+			for start := 0; start < len(list); start += rows {
+				end := min(start+rows, len(list))
+				columns = append(columns, list[start:end])
+				colWidth = append(colWidth, maxWidth(widths[start:end]))
+				total += colWidth[len(colWidth)-1] + twc.Gutter
+			}
+
+			if total <= maxLen || cols == 1 {
+				twc.printPackedColumns(columns, colWidth, indent)
+				return
+			}
+		}
+	})
+}
+
+// columnsWidth returns the width available for all the columns and the
+// gutters between them
+func (twc TWConf) columnsWidth(indent int) int {
+	return max(twc.MinCharsToPrint,
+		twc.TargetLineLen-strWidth(twc.lineStart(indent)))
+}
+
+// printColumns prints the columns side by side, each padded to the width
+func (twc TWConf) printColumns(columns [][]string, width, indent int) {
+	colWidth := make([]int, len(columns))
+	for i := range colWidth {
+		colWidth[i] = width
+	}
+
+	twc.printPackedColumns(columns, colWidth, indent)
+}
+
+// printPackedColumns prints the columns side by side, each column padded
+// to its width and separated by the Gutter. Any trailing spaces are
+// removed from each line.
+func (twc TWConf) printPackedColumns(
+	columns [][]string, colWidth []int, indent int,
+) {
+	if len(columns) == 0 {
+		return
+	}
+
+	gutter := strings.Repeat(" ", twc.Gutter)
+
+	for row := range columns[0] {
+		var b strings.Builder
+
+		for i, col := range columns {
+			if row >= len(col) {
+				break
+			}
+
+			if i > 0 {
+				b.WriteString(gutter)
+			}
+
+			b.WriteString(col[row])
+			b.WriteString(strings.Repeat(" ",
+				max(colWidth[i]-strWidth(col[row]), 0)))
+		}
+
+		line := strings.TrimRight(b.String(), " ")
+		if line == "" {
+			twc.Print(twc.blankLine())
+			continue
+		}
+
+		twc.Print(twc.lineStart(indent) + line + "\n")
+	}
+}
@@ -0,0 +1,110 @@
+package twrap_test
+
+import (
+	"testing"
+
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+	"github.com/nickwells/twrap.mod/twrap"
+)
+
+func TestColumns(t *testing.T) {
+	const text = "one two three four five six seven eight nine ten" +
+		" eleven twelve thirteen"
+
+	testCases := []struct {
+		testhelper.ID
+		opts     []twrap.TWConfOptFunc
+		cols     int
+		indent   int
+		expLines []string
+	}{
+		{
+			ID:   testhelper.MkID("one column"),
+			cols: 1,
+			expLines: []string{
+				"one two three four five six",
+				"seven eight nine ten eleven",
+				"twelve thirteen",
+			},
+		},
+		{
+			ID:     testhelper.MkID("two columns, indented"),
+			cols:   2,
+			indent: 2,
+			expLines: []string{
+				"  one two three  nine ten",
+				"  four five six  eleven twelve",
+				"  seven eight    thirteen",
+			},
+		},
+		{
+			ID:   testhelper.MkID("three columns, wide gutter"),
+			opts: []twrap.TWConfOptFunc{twrap.SetGutter(4)},
+			cols: 3,
+			expLines: []string{
+				"one two    six        ten",
+				"three      seven      eleven",
+				"four       eight      twelve",
+				"five       nine       thirteen",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		opts := append([]twrap.TWConfOptFunc{
+			twrap.SetMinChars(5),
+			twrap.SetTargetLineLen(30),
+		}, tc.opts...)
+		twc := twrap.NewTWConfOrPanic(opts...)
+
+		testhelper.DiffStringSlice(t, tc.IDStr(), "column lines",
+			twc.ColumnsLines(text, tc.cols, tc.indent), tc.expLines)
+	}
+}
+
+func TestListColumns(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		list     []string
+		expLines []string
+	}{
+		{
+			ID: testhelper.MkID("empty"),
+		},
+		{
+			ID:       testhelper.MkID("all on one line"),
+			list:     []string{"add", "commit", "push"},
+			expLines: []string{"  add  commit  push"},
+		},
+		{
+			ID: testhelper.MkID("packed"),
+			list: []string{
+				"add", "bisect", "branch", "checkout", "clone",
+				"commit", "diff", "fetch", "grep", "init", "log",
+			},
+			expLines: []string{
+				"  add       clone   grep",
+				"  bisect    commit  init",
+				"  branch    diff    log",
+				"  checkout  fetch",
+			},
+		},
+		{
+			ID:   testhelper.MkID("too wide"),
+			list: []string{"a-very-long-subcommand-name", "another-long-one"},
+			expLines: []string{
+				"  a-very-long-subcommand-name",
+				"  another-long-one",
+			},
+		},
+	}
+
+	twc := twrap.NewTWConfOrPanic(
+		twrap.SetMinChars(5),
+		twrap.SetTargetLineLen(30))
+
+	for _, tc := range testCases {
+		testhelper.DiffStringSlice(t, tc.IDStr(), "column lines",
+			twc.ListColumnsLines(tc.list, 2), tc.expLines)
+	}
+}
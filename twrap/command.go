@@ -0,0 +1,266 @@
+package twrap
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	// cmdBreakMarker ends each broken line of a command
+	cmdBreakMarker = " \\"
+	// cmdContIndent is the extra indent of the continuation lines of a
+	// command
+	cmdContIndent = 4
+)
+
+// WrapCommand will print the shell command line wrapped so that it can
+// still be copied and pasted into a shell. The command is split into words
+// as the shell would split it and lines are only broken between words so
+// quoted arguments are never broken. A flag (a word starting with '-') and
+// the value following it are kept on the same line if they will fit. Each
+// broken line ends with a " \" continuation and the following lines are
+// indented by a further four spaces. A comment (starting with an unquoted
+// '#') is never broken and ends its line; any words following it start a
+// new command.
+//
+// If MaxLines is set then no more than that many lines are printed, as for
+// Wrap3Indent, and the last line printed before the TruncMarker doesn't
+// have a continuation.
+//
+// It returns the number of bytes written and any error encountered.
+func (twc TWConf) WrapCommand(cmd string, indent int) (int, error) {
+	return twc.captureLimited(indent, func(twc TWConf) {
+		twc.wrapCommand(cmd, indent)
+	})
+}
+
+// cmdLine is a line of a wrapped command
+type cmdLine struct {
+	text      string
+	continued bool // the command continues on the next line
+}
+
+// wrapCommand performs the work of WrapCommand. It expects the writer to
+// record any errors.
+func (twc TWConf) wrapCommand(cmd string, indent int) {
+	args := groupCmdArgs(shellWords(cmd))
+	if len(args) == 0 {
+		return
+	}
+
+	twc.BreakMarker = cmdBreakMarker
+
+	prefix := twc.lineStart(indent)
+	maxLen := twc.calcMaxLen(indent)
+	contMaxLen := twc.calcMaxLen(indent + cmdContIndent)
+
+	var (
+		lines   []cmdLine
+		line    []string
+		lineLen int
+	)
+
+	endLine := func(continued bool) {
+		lines = append(lines, cmdLine{
+			text:      prefix + strings.Join(line, " "),
+			continued: continued,
+		})
+
+		prefix = twc.lineStart(indent + cmdContIndent)
+		maxLen = contMaxLen
+		line = line[:0]
+		lineLen = 0
+	}
+
+	for _, arg := range args {
+		words := []string{strings.Join(arg, " ")}
+		if len(arg) > 1 && strWidth(words[0]) > contMaxLen {
+			words = arg
+		}
+
+		for _, w := range words {
+			wLen := strWidth(w)
+			if len(line) > 0 {
+				if lineLen+1+wLen > maxLen {
+					endLine(true)
+				} else {
+					lineLen++
+				}
+			}
+
+			line = append(line, w)
+			lineLen += wLen
+
+			if isCmdComment(w) {
+				// a continuation would be part of the comment so the
+				// words after it start a new command
+				endLine(false)
+
+				prefix = twc.lineStart(indent)
+				maxLen = twc.calcMaxLen(indent)
+			}
+		}
+	}
+
+	if len(line) > 0 {
+		endLine(false)
+	}
+
+	// the line before the TruncMarker must not continue onto it
+	if twc.MaxLines > 1 && len(lines) > twc.MaxLines {
+		lines[twc.MaxLines-2].continued = false
+	}
+
+	for _, l := range lines {
+		if l.continued {
+			l.text += cmdBreakMarker
+		}
+
+		twc.Print(l.text + "\n")
+	}
+}
+
+// shellWords splits the command line into words as the shell would. The
+// words are returned as they appear in the command, with any quotes and
+// escapes still in place. A backslash at the end of a line is taken as a
+// line continuation and is removed. A comment is returned as a single word
+// running to the end of its line.
+func shellWords(cmd string) []string {
+	var (
+		words     []string
+		word      strings.Builder
+		inWord    bool
+		inComment bool
+		quote     rune
+		escaped   bool
+	)
+
+	endWord := func() {
+		if inWord {
+			words = append(words, word.String())
+			word.Reset()
+			inWord = false
+		}
+	}
+
+	endComment := func() {
+		words = append(words,
+			strings.TrimRightFunc(word.String(), unicode.IsSpace))
+		word.Reset()
+		inWord = false
+		inComment = false
+	}
+
+	for _, r := range cmd {
+		switch {
+		case inComment:
+			if r == '\n' {
+				endComment()
+				continue
+			}
+
+			word.WriteRune(r)
+		case escaped:
+			escaped = false
+
+			if r == '\n' {
+				if quote == 0 {
+					endWord()
+				}
+
+				continue
+			}
+
+			inWord = true
+
+			word.WriteRune('\\')
+			word.WriteRune(r)
+		case r == '\\' && quote != '\'':
+			escaped = true
+		case quote != 0:
+			word.WriteRune(r)
+
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+
+			word.WriteRune(r)
+		case unicode.IsSpace(r):
+			endWord()
+		case r == '#' && !inWord:
+			inComment = true
+			inWord = true
+
+			word.WriteRune(r)
+		default:
+			inWord = true
+
+			word.WriteRune(r)
+		}
+	}
+
+	if escaped {
+		inWord = true
+
+		word.WriteRune('\\')
+	}
+
+	if inComment {
+		endComment()
+	}
+
+	endWord()
+
+	return words
+}
+
+// isCmdComment returns true if the word is a comment
+func isCmdComment(w string) bool {
+	return strings.HasPrefix(w, "#")
+}
+
+// isShellOperator returns true if the word is a shell control operator
+func isShellOperator(w string) bool {
+	switch w {
+	case "|", "||", "&&", ";", "&", "|&":
+		return true
+	}
+
+	return false
+}
+
+// isCmdFlag returns true if the word looks like a flag which might take a
+// following value
+func isCmdFlag(w string) bool {
+	return len(w) > 1 && w[0] == '-' && w != "--" &&
+		!strings.Contains(w, "=")
+}
+
+// groupCmdArgs groups the words of the command so that each flag is
+// grouped with the value following it (if any). All other words, including
+// comments, are in groups of their own.
+func groupCmdArgs(words []string) [][]string {
+	args := make([][]string, 0, len(words))
+
+	for i := 0; i < len(words); i++ {
+		w := words[i]
+
+		if i+1 < len(words) && isCmdFlag(w) {
+			next := words[i+1]
+			if next[0] != '-' && !isShellOperator(next) &&
+				!isCmdComment(next) {
+				args = append(args, []string{w, next})
+				i++
+
+				continue
+			}
+		}
+
+		args = append(args, []string{w})
+	}
+
+	return args
+}
@@ -0,0 +1,83 @@
+package twrap_test
+
+import (
+	"testing"
+
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+	"github.com/nickwells/twrap.mod/twrap"
+)
+
+func TestWrapCommand(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		cmd      string
+		indent   int
+		expLines []string
+	}{
+		{
+			ID:       testhelper.MkID("short"),
+			cmd:      "ls -l /tmp",
+			expLines: []string{"ls -l /tmp"},
+		},
+		{
+			ID:  testhelper.MkID("empty"),
+			cmd: "  ",
+		},
+		{
+			ID: testhelper.MkID("quotes and flag values"),
+			cmd: `mycmd -name "a quoted value" -v --out=file.txt` +
+				` -count 10 'single quoted'`,
+			indent: 2,
+			expLines: []string{
+				`  mycmd -name "a quoted value" \`,
+				`      -v --out=file.txt \`,
+				`      -count 10 \`,
+				`      'single quoted'`,
+			},
+		},
+		{
+			ID:  testhelper.MkID("escapes and continuations"),
+			cmd: "echo a\\ b \\\n  c\\\"d | grep -e \"x\\\"y\" -e z",
+			expLines: []string{
+				`echo a\ b c\"d | grep \`,
+				`    -e "x\"y" -e z`,
+			},
+		},
+		{
+			ID:  testhelper.MkID("flag and value too long"),
+			cmd: "cmd -file /a/very/long/path/name/that/is/long",
+			expLines: []string{
+				`cmd -file \`,
+				`    /a/very/long/path/name/that/is/long`,
+			},
+		},
+		{
+			ID:     testhelper.MkID("comment"),
+			cmd:    "ls -l /tmp # a comment that is long",
+			indent: 2,
+			expLines: []string{
+				`  ls -l /tmp \`,
+				`      # a comment that is long`,
+			},
+		},
+		{
+			ID: testhelper.MkID("comment then a command"),
+			cmd: "ls -l # a comment\n" +
+				"echo a 'b # c' d\\#e f g h i j k l m n o",
+			expLines: []string{
+				`ls -l # a comment`,
+				`echo a 'b # c' d\#e f g h i j \`,
+				`    k l m n o`,
+			},
+		},
+	}
+
+	twc := twrap.NewTWConfOrPanic(
+		twrap.SetMinChars(10),
+		twrap.SetTargetLineLen(32))
+
+	for _, tc := range testCases {
+		testhelper.DiffStringSlice(t, tc.IDStr(), "wrapped lines",
+			twc.WrapCommandLines(tc.cmd, tc.indent), tc.expLines)
+	}
+}
@@ -0,0 +1,71 @@
+package twrap_test
+
+import (
+	"testing"
+
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+	"github.com/nickwells/twrap.mod/twrap"
+)
+
+func TestContinuationMarkers(t *testing.T) {
+	const text = "a long line of text which will need to be wrapped\n" +
+		"- an item which wraps too"
+
+	testCases := []struct {
+		testhelper.ID
+		opts     []twrap.TWConfOptFunc
+		expLines []string
+	}{
+		{
+			ID: testhelper.MkID("break marker"),
+			opts: []twrap.TWConfOptFunc{
+				twrap.SetBreakMarker(" \\"),
+			},
+			expLines: []string{
+				"  a long line of text \\",
+				"  which will need to be \\",
+				"  wrapped",
+				"  - an item which wraps \\",
+				"    too",
+			},
+		},
+		{
+			ID: testhelper.MkID("continuation marker"),
+			opts: []twrap.TWConfOptFunc{
+				twrap.SetContMarker("↪ "),
+			},
+			expLines: []string{
+				"  a long line of text",
+				"  ↪ which will need to be",
+				"  ↪ wrapped",
+				"  - an item which wraps",
+				"  ↪   too",
+			},
+		},
+		{
+			ID: testhelper.MkID("both markers, justified"),
+			opts: []twrap.TWConfOptFunc{
+				twrap.SetBreakMarker("↩"),
+				twrap.SetContMarker("↪ "),
+				twrap.SetAlignment(twrap.AlignJustify),
+			},
+			expLines: []string{
+				"  a  long  line  of  text↩",
+				"  ↪ which will need to be↩",
+				"  ↪ wrapped",
+				"  - an  item  which wraps↩",
+				"  ↪   too",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		opts := append([]twrap.TWConfOptFunc{
+			twrap.SetMinChars(10),
+			twrap.SetTargetLineLen(26),
+		}, tc.opts...)
+		twc := twrap.NewTWConfOrPanic(opts...)
+
+		testhelper.DiffStringSlice(t, tc.IDStr(), "wrapped lines",
+			twc.WrapLines(text, 2), tc.expLines)
+	}
+}
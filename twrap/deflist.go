@@ -0,0 +1,57 @@
+package twrap
+
+import "strings"
+
+// DfltDefTermMaxWidth is the default maximum width of the column of terms
+// printed by DefList
+const DfltDefTermMaxWidth = 20
+
+// DefListItem is a term and its description, as printed by DefList
+type DefListItem struct {
+	Term string
+	Desc string
+}
+
+// DefList will print the terms and their descriptions in the way that man
+// pages show a list of options. The terms are printed at the indent and
+// the descriptions in a column to their right, separated by the Gutter.
+// The column of terms is as wide as the widest term but no wider than the
+// DefTermMaxWidth. If a term fits in the column its description starts on
+// the same line, otherwise the description starts on the next line. The
+// descriptions are wrapped and every line of a description, including the
+// first line of any subsequent paragraphs, starts at the description
+// column. The Align setting applies only to the descriptions; the terms
+// are always printed at the indent.
+//
+// It returns the number of bytes written and any error encountered.
+func (twc TWConf) DefList(defs []DefListItem, indent int) (int, error) {
+	return twc.captureLimited(indent, func(twc TWConf) {
+		termWidth := 0
+		for _, d := range defs {
+			termWidth = max(termWidth, strWidth(d.Term))
+		}
+
+		termWidth = min(termWidth, twc.DefTermMaxWidth)
+		descIndent := indent + termWidth + twc.Gutter
+
+		for _, d := range defs {
+			tw := strWidth(d.Term)
+
+			switch {
+			case d.Desc == "":
+				twc.wrap3Indent(d.Term, indent, indent, indent)
+			case tw > termWidth:
+				twc.wrap3Indent(d.Term, indent, indent, indent)
+				twc.wrap3Indent(d.Desc, descIndent, descIndent, descIndent)
+			default:
+				// the term is printed ahead of the first line of the
+				// description so that it is not moved when the
+				// description is aligned
+				w := newWrapper(twc, descIndent, descIndent, descIndent)
+				w.line1Prefix = twc.lineStart(indent) + d.Term +
+					strings.Repeat(" ", descIndent-indent-tw)
+				w.wrapParas(d.Desc)
+			}
+		}
+	})
+}
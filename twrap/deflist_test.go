@@ -0,0 +1,92 @@
+package twrap_test
+
+import (
+	"testing"
+
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+	"github.com/nickwells/twrap.mod/twrap"
+)
+
+func TestDefList(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		opts     []twrap.TWConfOptFunc
+		defs     []twrap.DefListItem
+		indent   int
+		expLines []string
+	}{
+		{
+			ID: testhelper.MkID("empty"),
+		},
+		{
+			ID: testhelper.MkID("terms fit"),
+			defs: []twrap.DefListItem{
+				{Term: "-v", Desc: "print more details of the work being done"},
+				{Term: "--help", Desc: "show the help message"},
+			},
+			indent: 2,
+			expLines: []string{
+				"  -v      print more details of the",
+				"          work being done",
+				"  --help  show the help message",
+			},
+		},
+		{
+			ID:   testhelper.MkID("term too wide, paragraphs, no desc"),
+			opts: []twrap.TWConfOptFunc{twrap.SetDefTermMaxWidth(6)},
+			defs: []twrap.DefListItem{
+				{Term: "-n", Desc: "dry run\nnothing is changed"},
+				{Term: "--recursive", Desc: "descend into directories"},
+				{Term: "--", Desc: ""},
+			},
+			expLines: []string{
+				"-n      dry run",
+				"        nothing is changed",
+				"--recursive",
+				"        descend into directories",
+				"--",
+			},
+		},
+		{
+			ID:   testhelper.MkID("justified"),
+			opts: []twrap.TWConfOptFunc{twrap.SetAlignment(twrap.AlignJustify)},
+			defs: []twrap.DefListItem{
+				{
+					Term: "-v",
+					Desc: "print more details of the job being done and more",
+				},
+				{Term: "-x", Desc: "\nstarts on the next line"},
+			},
+			indent: 2,
+			expLines: []string{
+				"  -v  print  more details of the job",
+				"      being done and more",
+				"  -x",
+				"      starts on the next line",
+			},
+		},
+		{
+			ID:   testhelper.MkID("right aligned"),
+			opts: []twrap.TWConfOptFunc{twrap.SetAlignment(twrap.AlignRight)},
+			defs: []twrap.DefListItem{
+				{Term: "-v", Desc: "print more details of the job being done"},
+			},
+			indent: 2,
+			expLines: []string{
+				"  -v   print more details of the job",
+				"                          being done",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		opts := append([]twrap.TWConfOptFunc{
+			twrap.SetMinChars(5),
+			twrap.SetTargetLineLen(36),
+		}, tc.opts...)
+		twc := twrap.NewTWConfOrPanic(opts...)
+
+		testhelper.DiffStringSlice(t, tc.IDStr(), "definition lines",
+			twc.DefListLines(tc.defs, tc.indent), tc.expLines)
+	}
+}
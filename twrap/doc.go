@@ -4,8 +4,33 @@ allows you to print indented text that will fit within a supplied maximum
 line length. It is also possible to specify a minimum amount of text to print
 on each line in case the indent is too great.
 
+Line lengths are measured in terminal cells rather than bytes or runes so
+East Asian wide characters count as two cells and combining marks and other
+zero-width characters count as none. ANSI escape sequences (CSI and OSC)
+also take no space and are never split. Any colour or other style that is in
+effect when a line is broken is reset before the newline and restored after
+the indent so that it doesn't leak into the padding.
+
+Words which don't fit at the end of a line can be hyphenated. Any soft
+hyphens (U+00AD) in a word mark the points where it may be broken; otherwise
+the TWConf's Hyphenator, if set, is used to find them. The
+EnglishHyphenator function returns a Hyphenator using the standard TeX
+patterns for American English.
+
+Code samples, diagrams and other preformatted text can be kept as they are
+by setting a VerbatimIndent (paragraphs indented by at least this much are
+not wrapped) or a VerbatimFence (the paragraphs between fences are not
+wrapped).
+
+By default each newline starts a new paragraph. Text which has already been
+broken into lines, such as a Go doc comment, can be rewrapped by setting
+BlankLineParas so that only blank lines separate paragraphs. The paragraph
+break can also be given as a regular expression (see SetParaBreak).
+
 You should first of all create a TWConf (use the NewTWConf function - it will
 report invalid parameters). Then you can call the various Wrap...(...)
-methods on it to print the text.
+methods on it to print the text. As with the fmt.Fprint functions, these
+methods return the number of bytes written and any error encountered while
+writing; once a write has failed nothing further is written.
 */
 package twrap
@@ -1,6 +1,8 @@
 package twrap_test
 
 import (
+	"fmt"
+
 	"github.com/nickwells/twrap.mod/twrap"
 )
 
@@ -258,3 +260,22 @@ func ExampleTWConf_NoRptPathListItem() {
 	//     -          124
 	//     -      ghi/123
 }
+
+// ExampleNewWriter provides an example of how the twrap.NewWriter func
+// might be used. The text is wrapped as it is written so there is no need
+// to construct the whole text before printing it.
+func ExampleNewWriter() {
+	twc := twrap.NewTWConfOrPanic(twrap.SetTargetLineLen(40))
+
+	w := twrap.NewWriter(*twc, 4)
+	for i, s := range []string{"mercy", "rain", "place"} {
+		fmt.Fprintf(w, "line %d mentions %q. ", i+1, s)
+	}
+
+	w.Close()
+
+	// Output:
+	//     line 1 mentions "mercy". line 2
+	//     mentions "rain". line 3 mentions
+	//     "place".
+}
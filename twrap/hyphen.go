@@ -0,0 +1,139 @@
+package twrap
+
+import (
+	"slices"
+	"strings"
+)
+
+const (
+	softHyphen = '\u00ad'
+	hyphen     = "-"
+)
+
+// Hyphenator is the interface that wraps the Hyphenate method.
+//
+// Hyphenate returns the offsets in bytes within the word at which it may be
+// broken with a hyphen, in increasing order. The word may include
+// punctuation, escape sequences and so on; any offsets within an escape
+// sequence are ignored.
+type Hyphenator interface {
+	Hyphenate(word string) []int
+}
+
+// hyphenPoints removes any soft hyphens from the word and returns the
+// resulting word and the offsets at which it may be hyphenated. If the word
+// contained any soft hyphens then they give the only hyphenation points,
+// otherwise the points are found using the Hyphenator, if any.
+func (twc TWConf) hyphenPoints(word string) (string, []int) {
+	if strings.ContainsRune(word, softHyphen) {
+		var (
+			b      strings.Builder
+			points []int
+		)
+
+		for _, r := range word {
+			if r == softHyphen {
+				if b.Len() > 0 {
+					points = append(points, b.Len())
+				}
+
+				continue
+			}
+
+			b.WriteRune(r)
+		}
+
+		word = b.String()
+
+		if len(points) > 0 && points[len(points)-1] == len(word) {
+			points = points[:len(points)-1]
+		}
+
+		return word, slices.Compact(points)
+	}
+
+	if twc.Hyphenator == nil {
+		return word, nil
+	}
+
+	var points []int
+
+	for _, p := range twc.Hyphenator.Hyphenate(word) {
+		if p > 0 && p < len(word) && !inEscSeq(word, p) {
+			points = append(points, p)
+		}
+	}
+
+	return word, points
+}
+
+// inEscSeq returns true if the offset is within an escape sequence in s
+func inEscSeq(s string, offset int) bool {
+	for i := 0; i < offset; i++ {
+		if n := escSeqLen(s[i:]); n > 0 {
+			if offset < i+n {
+				return true
+			}
+
+			i += n - 1
+		}
+	}
+
+	return false
+}
+
+// splitAtHyphens splits the word at each of its hyphenation points. The
+// parts after the first are marked as glued to the preceding part so that
+// they are only separated (with a hyphen) if the line is broken there.
+func splitAtHyphens(lw lineWord) []lineWord {
+	parts := make([]lineWord, 0, len(lw.hyphenAt)+1)
+	prev := 0
+
+	for _, p := range append(lw.hyphenAt, len(lw.word)) {
+		part := lineWord{
+			word:    lw.word[prev:p],
+			wordLen: strWidth(lw.word[prev:p]),
+			glued:   prev > 0,
+		}
+		if prev == 0 {
+			part.spaces, part.spacesLen = lw.spaces, lw.spacesLen
+		}
+
+		parts = append(parts, part)
+		prev = p
+	}
+
+	return parts
+}
+
+// hyphenate splits the word at the last hyphenation point which will leave
+// the first part, with a hyphen added, fitting in the given width. It
+// returns false if there is no such point.
+func hyphenate(lw lineWord, width int) (head, tail lineWord, ok bool) {
+	for i := len(lw.hyphenAt) - 1; i >= 0; i-- {
+		p := lw.hyphenAt[i]
+
+		h := lw.word[:p] + hyphen
+		if hLen := strWidth(h); hLen <= width {
+			head = lineWord{
+				spaces:    lw.spaces,
+				spacesLen: lw.spacesLen,
+				word:      h,
+				wordLen:   hLen,
+			}
+			tail = lineWord{
+				word:     lw.word[p:],
+				wordLen:  strWidth(lw.word[p:]),
+				hyphenAt: make([]int, 0, len(lw.hyphenAt)-i-1),
+			}
+
+			for _, tp := range lw.hyphenAt[i+1:] {
+				tail.hyphenAt = append(tail.hyphenAt, tp-p)
+			}
+
+			return head, tail, true
+		}
+	}
+
+	return lw, lineWord{}, false
+}
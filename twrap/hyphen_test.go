@@ -0,0 +1,217 @@
+package twrap_test
+
+import (
+	"testing"
+
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+	"github.com/nickwells/twrap.mod/twrap"
+)
+
+func TestEnglishHyphenator(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		word      string
+		expPoints []int
+	}{
+		{
+			ID:        testhelper.MkID("hyphenation"),
+			word:      "hyphenation",
+			expPoints: []int{2, 6, 7},
+		},
+		{
+			ID:        testhelper.MkID("capitalised, with punctuation"),
+			word:      "(Hyphenation),",
+			expPoints: []int{3, 7, 8},
+		},
+		{
+			ID:   testhelper.MkID("too short"),
+			word: "word",
+		},
+		{
+			ID:        testhelper.MkID("exception"),
+			word:      "project",
+			expPoints: []int{},
+		},
+		{
+			ID:        testhelper.MkID("exception, with escape sequences"),
+			word:      "\x1b[1mproject\x1b[0m",
+			expPoints: []int{},
+		},
+		{
+			ID:        testhelper.MkID("with escape sequences"),
+			word:      "\x1b[1mhyphenation\x1b[0m",
+			expPoints: []int{6, 10, 11},
+		},
+		{
+			ID:        testhelper.MkID("escape sequence within the word"),
+			word:      "hyph\x1b[31menation",
+			expPoints: []int{2, 11, 12},
+		},
+		{
+			ID:        testhelper.MkID("multi-byte capital"),
+			word:      "İnternational",
+			expPoints: []int{3, 6, 8},
+		},
+	}
+
+	h := twrap.EnglishHyphenator()
+
+	for _, tc := range testCases {
+		points := h.Hyphenate(tc.word)
+		if len(points) == 0 && len(tc.expPoints) == 0 {
+			continue
+		}
+
+		testhelper.DiffSlice(t, tc.IDStr(), "hyphenation points",
+			points, tc.expPoints)
+	}
+}
+
+func TestNewLiangHyphenator(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		testhelper.ExpErr
+		patterns   string
+		exceptions string
+		word       string
+		expPoints  []int
+	}{
+		{
+			ID:         testhelper.MkID("good"),
+			patterns:   "% a comment\n1ba\n",
+			exceptions: "ab-aba",
+			word:       "ababab",
+			expPoints:  []int{3},
+		},
+		{
+			ID:         testhelper.MkID("exception"),
+			patterns:   "1ba",
+			exceptions: "ab-aba",
+			word:       "ababa",
+			expPoints:  []int{2},
+		},
+		{
+			ID:       testhelper.MkID("bad - two digits"),
+			ExpErr:   testhelper.MkExpErr("bad hyphenation pattern", "12ba"),
+			patterns: "12ba",
+		},
+		{
+			ID:       testhelper.MkID("bad - no letters"),
+			ExpErr:   testhelper.MkExpErr("bad hyphenation pattern", "1"),
+			patterns: "1",
+		},
+	}
+
+	for _, tc := range testCases {
+		h, err := twrap.NewLiangHyphenator(tc.patterns, tc.exceptions)
+		if testhelper.CheckExpErr(t, err, tc) && err == nil {
+			testhelper.DiffSlice(t, tc.IDStr(), "hyphenation points",
+				h.Hyphenate(tc.word), tc.expPoints)
+		}
+	}
+}
+
+func TestHyphenate(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		opts     []twrap.TWConfOptFunc
+		text     string
+		expLines []string
+	}{
+		{
+			ID:   testhelper.MkID("no hyphenator"),
+			text: "the hyphenation of words",
+			expLines: []string{
+				"the",
+				"hyphenation",
+				"of words",
+			},
+		},
+		{
+			ID: testhelper.MkID("English hyphenator"),
+			opts: []twrap.TWConfOptFunc{
+				twrap.SetHyphenator(twrap.EnglishHyphenator()),
+			},
+			text: "the hyphenation of words",
+			expLines: []string{
+				"the hyphen-",
+				"ation of",
+				"words",
+			},
+		},
+		{
+			ID:   testhelper.MkID("soft hyphens"),
+			text: "the hy­phen­ation of words",
+			expLines: []string{
+				"the hyphen-",
+				"ation of",
+				"words",
+			},
+		},
+		{
+			ID: testhelper.MkID("soft hyphens override the hyphenator"),
+			opts: []twrap.TWConfOptFunc{
+				twrap.SetHyphenator(twrap.EnglishHyphenator()),
+			},
+			text: "the hy­phenation of words",
+			expLines: []string{
+				"the hy-",
+				"phenation",
+				"of words",
+			},
+		},
+		{
+			ID:   testhelper.MkID("soft hyphens, unbroken"),
+			text: "a hy­phen",
+			expLines: []string{
+				"a hyphen",
+			},
+		},
+		{
+			ID: testhelper.MkID("long word"),
+			opts: []twrap.TWConfOptFunc{
+				twrap.SetHyphenator(twrap.EnglishHyphenator()),
+			},
+			text: "incomprehensibilities",
+			expLines: []string{
+				"incompre-",
+				"hensibili-",
+				"ties",
+			},
+		},
+		{
+			ID: testhelper.MkID("optimal"),
+			opts: []twrap.TWConfOptFunc{
+				twrap.SetHyphenator(twrap.EnglishHyphenator()),
+				twrap.SetBreakStrategy(twrap.BreakOptimal),
+			},
+			text: "the hyphenation of words",
+			expLines: []string{
+				"the hyphen-",
+				"ation of",
+				"words",
+			},
+		},
+		{
+			ID: testhelper.MkID("optimal, soft hyphens, unbroken"),
+			opts: []twrap.TWConfOptFunc{
+				twrap.SetBreakStrategy(twrap.BreakOptimal),
+			},
+			text: "a hy­phen",
+			expLines: []string{
+				"a hyphen",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		opts := append([]twrap.TWConfOptFunc{
+			twrap.SetMinChars(5),
+			twrap.SetTargetLineLen(11),
+		}, tc.opts...)
+		twc := twrap.NewTWConfOrPanic(opts...)
+
+		testhelper.DiffStringSlice(t, tc.IDStr(), "wrapped lines",
+			twc.WrapLines(tc.text, 0), tc.expLines)
+	}
+}
@@ -0,0 +1,163 @@
+package twrap
+
+import (
+	"strconv"
+	"strings"
+)
+
+// IdxNumbering describes how the index numbers of the IdxList, IdxNoRptList
+// and IdxNoRptPathList methods are written
+type IdxNumbering int
+
+// These are the available numbering systems. The default is IdxDecimal.
+//
+// IdxLowerAlpha and IdxUpperAlpha number the items a, b, ... z, aa, ab and
+// so on (as spreadsheet columns are named).
+//
+// IdxLowerRoman and IdxUpperRoman number the items i, ii, iii, iv and so
+// on. Roman numerals are only available from 1 to 3999.
+//
+// Any index which cannot be written in the chosen system (an index of zero,
+// for instance) is written as a decimal number.
+const (
+	IdxDecimal IdxNumbering = iota
+	IdxLowerAlpha
+	IdxUpperAlpha
+	IdxLowerRoman
+	IdxUpperRoman
+)
+
+// IsValid returns true if the IdxNumbering is one of the known values
+func (n IdxNumbering) IsValid() bool {
+	return n >= IdxDecimal && n <= IdxUpperRoman
+}
+
+// IdxAlignment describes how the indexes printed by the IdxList,
+// IdxNoRptList and IdxNoRptPathList methods are aligned when they are not
+// all the same width
+type IdxAlignment int
+
+// These are the available index alignments. The default is IdxAlignRight.
+const (
+	IdxAlignRight IdxAlignment = iota
+	IdxAlignLeft
+)
+
+// IsValid returns true if the IdxAlignment is one of the known values
+func (a IdxAlignment) IsValid() bool {
+	return a >= IdxAlignRight && a <= IdxAlignLeft
+}
+
+// These are the default values for the index settings. They are used
+// unless they have been changed by SetIdxStart, SetIdxStep or
+// SetIdxSeparators so that a TWConf which has not been made by NewTWConf
+// still prints the default indexes.
+const (
+	DfltIdxStart = 1
+	DfltIdxStep  = 1
+	DfltIdxOpen  = ""
+	DfltIdxClose = ":"
+)
+
+// format returns the index written in the numbering system
+func (n IdxNumbering) format(idx int) string {
+	switch n {
+	case IdxLowerAlpha:
+		if idx > 0 {
+			return alphaIdx(idx)
+		}
+	case IdxUpperAlpha:
+		if idx > 0 {
+			return strings.ToUpper(alphaIdx(idx))
+		}
+	case IdxLowerRoman:
+		if idx > 0 && idx < 4000 {
+			return romanIdx(idx)
+		}
+	case IdxUpperRoman:
+		if idx > 0 && idx < 4000 {
+			return strings.ToUpper(romanIdx(idx))
+		}
+	}
+
+	return strconv.Itoa(idx)
+}
+
+// alphaIdx returns the index, which must be greater than zero, written as
+// lower case letters
+func alphaIdx(idx int) string {
+	var s []byte
+
+	for ; idx > 0; idx = (idx - 1) / 26 {
+		s = append([]byte{byte('a' + (idx-1)%26)}, s...)
+	}
+
+	return string(s)
+}
+
+// romanNumerals holds the values of the roman numerals, largest first,
+// including the subtractive pairs
+var romanNumerals = []struct {
+	val int
+	str string
+}{
+	{1000, "m"}, {900, "cm"}, {500, "d"}, {400, "cd"},
+	{100, "c"}, {90, "xc"}, {50, "l"}, {40, "xl"},
+	{10, "x"}, {9, "ix"}, {5, "v"}, {4, "iv"},
+	{1, "i"},
+}
+
+// romanIdx returns the index, which must be between 1 and 3999, written as
+// lower case roman numerals
+func romanIdx(idx int) string {
+	var b strings.Builder
+
+	for _, rn := range romanNumerals {
+		for ; idx >= rn.val; idx -= rn.val {
+			b.WriteString(rn.str)
+		}
+	}
+
+	return b.String()
+}
+
+// idxListPrefixes returns the prefixes for a list of the given number of
+// items. Each prefix is the ListPrefix followed by the index number,
+// between the index separators, and a space. The index numbers
+// are padded with spaces to the width of the widest so that the items line
+// up and they are aligned according to the IdxAlign value.
+func (twc TWConf) idxListPrefixes(count int) []string {
+	start := DfltIdxStart + twc.idxStartOffset
+
+	step := twc.IdxStep
+	if step <= 0 {
+		step = DfltIdxStep
+	}
+
+	open, closing := DfltIdxOpen, DfltIdxClose
+	if twc.idxSepsSet {
+		open, closing = twc.idxOpen, twc.idxClose
+	}
+
+	idxs := make([]string, 0, count)
+	width := 0
+
+	for i := range count {
+		idx := open + twc.IdxNumbering.format(start+i*step) + closing
+		idxs = append(idxs, idx)
+		width = max(width, strWidth(idx))
+	}
+
+	prefixes := make([]string, 0, count)
+
+	for _, idx := range idxs {
+		pad := strings.Repeat(" ", width-strWidth(idx))
+		if twc.IdxAlign == IdxAlignLeft {
+			prefixes = append(prefixes, twc.ListPrefix+idx+pad+" ")
+		} else {
+			prefixes = append(prefixes, twc.ListPrefix+pad+idx+" ")
+		}
+	}
+
+	return prefixes
+}
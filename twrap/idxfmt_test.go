@@ -0,0 +1,148 @@
+package twrap_test
+
+import (
+	"testing"
+
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+	"github.com/nickwells/twrap.mod/twrap"
+)
+
+func TestIdxFormat(t *testing.T) {
+	list := []string{"a", "b", "c", "d", "e"}
+
+	testCases := []struct {
+		testhelper.ID
+		opts     []twrap.TWConfOptFunc
+		list     []string
+		expLines []string
+	}{
+		{
+			ID:   testhelper.MkID("default"),
+			list: list[:2],
+			expLines: []string{
+				"- 1: a",
+				"- 2: b",
+			},
+		},
+		{
+			ID: testhelper.MkID("start, step, separators"),
+			opts: []twrap.TWConfOptFunc{
+				twrap.SetListPrefix(""),
+				twrap.SetIdxStart(0),
+				twrap.SetIdxStep(5),
+				twrap.SetIdxSeparators("[", "]"),
+			},
+			list: list[:3],
+			expLines: []string{
+				" [0] a",
+				" [5] b",
+				"[10] c",
+			},
+		},
+		{
+			ID: testhelper.MkID("left aligned upper roman"),
+			opts: []twrap.TWConfOptFunc{
+				twrap.SetListPrefix(""),
+				twrap.SetIdxSeparators("", "."),
+				twrap.SetIdxAlign(twrap.IdxAlignLeft),
+				twrap.SetIdxNumbering(twrap.IdxUpperRoman),
+				twrap.SetIdxStart(2),
+			},
+			list: list,
+			expLines: []string{
+				"II.  a",
+				"III. b",
+				"IV.  c",
+				"V.   d",
+				"VI.  e",
+			},
+		},
+		{
+			ID: testhelper.MkID("lower alpha"),
+			opts: []twrap.TWConfOptFunc{
+				twrap.SetListPrefix(""),
+				twrap.SetIdxSeparators("", ")"),
+				twrap.SetIdxNumbering(twrap.IdxLowerAlpha),
+				twrap.SetIdxStart(25),
+			},
+			list: list[:4],
+			expLines: []string{
+				" y) a",
+				" z) b",
+				"aa) c",
+				"ab) d",
+			},
+		},
+		{
+			ID: testhelper.MkID("upper alpha and zero"),
+			opts: []twrap.TWConfOptFunc{
+				twrap.SetListPrefix(""),
+				twrap.SetIdxNumbering(twrap.IdxUpperAlpha),
+				twrap.SetIdxStart(0),
+			},
+			list: list[:3],
+			expLines: []string{
+				"0: a",
+				"A: b",
+				"B: c",
+			},
+		},
+		{
+			ID: testhelper.MkID("lower roman"),
+			opts: []twrap.TWConfOptFunc{
+				twrap.SetListPrefix(""),
+				twrap.SetIdxNumbering(twrap.IdxLowerRoman),
+				twrap.SetIdxStart(1994),
+			},
+			list: list[:1],
+			expLines: []string{
+				"mcmxciv: a",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		twc := twrap.NewTWConfOrPanic(tc.opts...)
+
+		testhelper.DiffStringSlice(t, tc.IDStr(), "IdxList lines",
+			twc.IdxListLines(tc.list, 0), tc.expLines)
+		testhelper.DiffStringSlice(t, tc.IDStr(), "IdxNoRptList lines",
+			twc.IdxNoRptListLines(tc.list, 0), tc.expLines)
+		testhelper.DiffStringSlice(t, tc.IDStr(), "IdxNoRptPathList lines",
+			twc.IdxNoRptPathListLines(tc.list, 0), tc.expLines)
+	}
+}
+
+func TestIdxFormatErrs(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		testhelper.ExpErr
+		opt twrap.TWConfOptFunc
+	}{
+		{
+			ID:     testhelper.MkID("bad start"),
+			ExpErr: testhelper.MkExpErr("the starting index must be >= 0"),
+			opt:    twrap.SetIdxStart(-1),
+		},
+		{
+			ID:     testhelper.MkID("bad step"),
+			ExpErr: testhelper.MkExpErr("the index step must be > 0"),
+			opt:    twrap.SetIdxStep(0),
+		},
+		{
+			ID:     testhelper.MkID("bad alignment"),
+			ExpErr: testhelper.MkExpErr("bad index alignment: 99"),
+			opt:    twrap.SetIdxAlign(99),
+		},
+		{
+			ID:     testhelper.MkID("bad numbering"),
+			ExpErr: testhelper.MkExpErr("bad index numbering: 99"),
+			opt:    twrap.SetIdxNumbering(99),
+		},
+	}
+
+	for _, tc := range testCases {
+		_, err := twrap.NewTWConf(tc.opt)
+		testhelper.CheckExpErr(t, err, tc)
+	}
+}
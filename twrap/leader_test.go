@@ -0,0 +1,72 @@
+package twrap_test
+
+import (
+	"testing"
+
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+	"github.com/nickwells/twrap.mod/twrap"
+)
+
+func TestLineLeader(t *testing.T) {
+	twc := twrap.NewTWConfOrPanic(
+		twrap.SetMinChars(10),
+		twrap.SetTargetLineLen(24),
+		twrap.SetLineLeader("// "))
+
+	testhelper.DiffStringSlice(t, "Wrap3Indent", "wrapped lines",
+		twc.Wrap3IndentLines(
+			"the first paragraph which wraps\n\n"+
+				"- a bulleted item which also wraps",
+			0, 1, 2),
+		[]string{
+			"// the first paragraph",
+			"//   which wraps",
+			"//",
+			"//  - a bulleted item",
+			"//   which also wraps",
+		})
+
+	testhelper.DiffStringSlice(t, "Wrap, bullet", "wrapped lines",
+		twc.WrapLines("- a bulleted item which also wraps", 2),
+		[]string{
+			"//   - a bulleted item",
+			"//     which also wraps",
+		})
+
+	testhelper.DiffStringSlice(t, "List", "list lines",
+		twc.ListLines([]string{"an item which wraps around", "another"}, 1),
+		[]string{
+			"//  - an item which",
+			"//    wraps around",
+			"//  - another",
+		})
+
+	testhelper.DiffStringSlice(t, "NoRptList", "list lines",
+		twc.NoRptListLines([]string{"abc", "abd"}, 1),
+		[]string{
+			"//  - abc",
+			"//  -   d",
+		})
+
+	testhelper.DiffStringSlice(t, "IdxNoRptPathList", "list lines",
+		twc.IdxNoRptPathListLines([]string{"a/b", "a/c"}, 0),
+		[]string{
+			"// - 1: a/b",
+			"// - 2:   c",
+		})
+
+	quoted := twrap.NewTWConfOrPanic(
+		twrap.SetMinChars(10),
+		twrap.SetTargetLineLen(24),
+		twrap.SetLineLeader("> > "),
+		twrap.SetBlankLineParas(true))
+
+	testhelper.DiffStringSlice(t, "nested quote", "wrapped lines",
+		quoted.WrapLines("some quoted text\nwhich is rewrapped\n\nmore", 0),
+		[]string{
+			"> > some quoted text",
+			"> > which is rewrapped",
+			"> >",
+			"> > more",
+		})
+}
@@ -0,0 +1,253 @@
+package twrap
+
+import (
+	_ "embed" // used to embed the English hyphenation patterns
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// These are the default minimum number of letters which must be left
+// before and after a hyphen
+const (
+	DfltHyphenLeftMin  = 2
+	DfltHyphenRightMin = 3
+)
+
+//go:embed hyph/en-us.pat.txt
+var enUSPatterns string
+
+//go:embed hyph/en-us.hyp.txt
+var enUSExceptions string
+
+// LiangHyphenator is a Hyphenator using Frank Liang's algorithm (as used
+// by TeX). Words are hyphenated according to a set of patterns with any
+// words in the exceptions list hyphenated as given there.
+type LiangHyphenator struct {
+	patterns   map[string][]uint8
+	maxPatLen  int
+	exceptions map[string][]int
+
+	LeftMin  int // the minimum letters before the first hyphen
+	RightMin int // the minimum letters after the last hyphen
+}
+
+// NewLiangHyphenator creates a LiangHyphenator from the patterns and
+// exceptions. Each is a list of entries separated by white space; lines
+// starting with a '%' are ignored. The patterns are in the format used by
+// TeX, letters with digits between them giving the hyphenation values and
+// a '.' marking the start or end of the word (for instance "hen5at" or
+// ".ach4"). The exceptions are words with hyphens at the allowed break
+// points (for instance "ta-ble"). It returns an error if any pattern is
+// badly formed.
+func NewLiangHyphenator(patterns, exceptions string) (
+	*LiangHyphenator, error,
+) {
+	lh := &LiangHyphenator{
+		patterns:   map[string][]uint8{},
+		exceptions: map[string][]int{},
+		LeftMin:    DfltHyphenLeftMin,
+		RightMin:   DfltHyphenRightMin,
+	}
+
+	for _, p := range entries(patterns) {
+		if err := lh.addPattern(p); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, e := range entries(exceptions) {
+		lh.addException(e)
+	}
+
+	return lh, nil
+}
+
+// entries returns the white-space separated entries in the text, ignoring
+// any lines starting with a '%'
+func entries(text string) []string {
+	var ents []string
+
+	for line := range strings.Lines(text) {
+		if strings.HasPrefix(line, "%") {
+			continue
+		}
+
+		ents = append(ents, strings.Fields(line)...)
+	}
+
+	return ents
+}
+
+// addPattern adds the pattern to the LiangHyphenator
+func (lh *LiangHyphenator) addPattern(p string) error {
+	var (
+		letters strings.Builder
+		values  = []uint8{0}
+	)
+
+	for _, r := range p {
+		if r >= '0' && r <= '9' {
+			if values[len(values)-1] != 0 {
+				return fmt.Errorf("bad hyphenation pattern: %q", p)
+			}
+
+			values[len(values)-1] = uint8(r - '0')
+
+			continue
+		}
+
+		letters.WriteRune(unicode.ToLower(r))
+
+		values = append(values, 0)
+	}
+
+	if letters.Len() == 0 {
+		return fmt.Errorf("bad hyphenation pattern: %q", p)
+	}
+
+	lh.patterns[letters.String()] = values
+	lh.maxPatLen = max(lh.maxPatLen, len(values)-1)
+
+	return nil
+}
+
+// addException adds the hyphenated word to the LiangHyphenator's exceptions
+func (lh *LiangHyphenator) addException(e string) {
+	var (
+		word   strings.Builder
+		points []int
+	)
+
+	runes := 0
+
+	for _, r := range e {
+		if r == '-' {
+			points = append(points, runes)
+			continue
+		}
+
+		word.WriteRune(unicode.ToLower(r))
+
+		runes++
+	}
+
+	lh.exceptions[word.String()] = points
+}
+
+// Hyphenate returns the offsets in bytes within the word at which it may be
+// broken with a hyphen. Each run of letters in the word is hyphenated
+// separately so that any surrounding punctuation is ignored. Any escape
+// sequences are skipped; they don't end a run of letters.
+func (lh *LiangHyphenator) Hyphenate(word string) []int {
+	var (
+		offsets []int
+		letters []rune // the letters of the run, in lower case
+		starts  []int  // the offset in the word of each letter
+	)
+
+	endRun := func() {
+		for _, p := range lh.hyphenateLetters(letters) {
+			offsets = append(offsets, starts[p])
+		}
+
+		letters, starts = letters[:0], starts[:0]
+	}
+
+	for i := 0; i < len(word); {
+		if n := escSeqLen(word[i:]); n > 0 {
+			i += n
+			continue
+		}
+
+		r, n := utf8.DecodeRuneInString(word[i:])
+		if unicode.IsLetter(r) {
+			letters = append(letters, unicode.ToLower(r))
+			starts = append(starts, i)
+		} else {
+			endRun()
+		}
+
+		i += n
+	}
+
+	endRun()
+
+	return offsets
+}
+
+// hyphenateLetters returns the offsets in runes at which the letters, which
+// must be in lower case, may be broken with a hyphen
+func (lh *LiangHyphenator) hyphenateLetters(letters []rune) []int {
+	if len(letters) < lh.LeftMin+lh.RightMin {
+		return nil
+	}
+
+	points, ok := lh.exceptions[string(letters)]
+	if !ok {
+		points = lh.liangPoints(letters)
+	}
+
+	allowed := make([]int, 0, len(points))
+
+	for _, p := range points {
+		if p >= lh.LeftMin && p <= len(letters)-lh.RightMin {
+			allowed = append(allowed, p)
+		}
+	}
+
+	return allowed
+}
+
+// liangPoints applies the patterns to the word and returns the rune
+// offsets at which the word may be hyphenated
+func (lh *LiangHyphenator) liangPoints(runes []rune) []int {
+	word := []rune("." + string(runes) + ".")
+	values := make([]uint8, len(word)+1)
+
+	for i := range word {
+		for j := i + 1; j <= len(word) && j-i <= lh.maxPatLen; j++ {
+			pv, ok := lh.patterns[string(word[i:j])]
+			if !ok {
+				continue
+			}
+
+			for k, v := range pv {
+				values[i+k] = max(values[i+k], v)
+			}
+		}
+	}
+
+	var points []int
+
+	// values[i] is the value between word[i-1] and word[i]; the first
+	// letter is at word[1] so a break before runes[p] is at values[p+1]
+	for p := 1; p < len(runes); p++ {
+		if values[p+1]%2 == 1 {
+			points = append(points, p)
+		}
+	}
+
+	return points
+}
+
+// englishHyphenator parses the embedded English patterns, once only
+var englishHyphenator = sync.OnceValue(func() *LiangHyphenator {
+	lh, err := NewLiangHyphenator(enUSPatterns, enUSExceptions)
+	if err != nil {
+		panic(fmt.Errorf("the embedded English patterns are bad: %w", err))
+	}
+
+	return lh
+})
+
+// EnglishHyphenator returns a LiangHyphenator using the embedded patterns
+// for American English. The patterns are only parsed on the first call;
+// each LiangHyphenator returned shares them but has its own LeftMin and
+// RightMin values.
+func EnglishHyphenator() *LiangHyphenator {
+	lh := *englishHyphenator()
+	return &lh
+}
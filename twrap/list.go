@@ -1,79 +1,94 @@
 package twrap
 
 import (
-	"fmt"
 	"path/filepath"
 	"strings"
-
-	"github.com/nickwells/mathutil.mod/v2/mathutil"
+	"unicode/utf8"
 )
 
 // List will print the list of strings, one per line, with the appropriate
-// indent and with each item prefixed with the list prefix
-func (twc TWConf) List(list []string, indent int) {
-	for _, li := range list {
-		twc.WrapPrefixed(twc.ListPrefix, li, indent)
-	}
+// indent and with each item prefixed with the list prefix.
+//
+// It returns the number of bytes written and any error encountered. It
+// stops at the first error.
+//
+// If MaxLines is set then no more than that many lines are printed, as for
+// Wrap3Indent. This also applies to the other List methods.
+func (twc TWConf) List(list []string, indent int) (int, error) {
+	return twc.captureLimited(indent, func(twc TWConf) {
+		for _, li := range list {
+			twc.wrapPrefixed(twc.ListPrefix, li, indent)
+		}
+	})
 }
 
 // ListItem calls List it is simply a more convenient interface
-func (twc TWConf) ListItem(indent int, list ...string) {
-	twc.List(list, indent)
+func (twc TWConf) ListItem(indent int, list ...string) (int, error) {
+	return twc.List(list, indent)
 }
 
 // IdxList will print the list of strings, one per line, with the
-// appropriate indent and with each item prefixed with an index number
-func (twc TWConf) IdxList(list []string, indent int) {
-	digits := mathutil.Digits(len(list))
-	for i, li := range list {
-		twc.WrapPrefixed(
-			idxListPrefix(twc.ListPrefix, i+1, digits),
-			li,
-			indent)
-	}
+// appropriate indent and with each item prefixed with an index number. The
+// way the index is written can be changed; see SetIdxStart, SetIdxStep,
+// SetIdxSeparators, SetIdxAlign and SetIdxNumbering.
+func (twc TWConf) IdxList(list []string, indent int) (int, error) {
+	return twc.captureLimited(indent, func(twc TWConf) {
+		prefixes := twc.idxListPrefixes(len(list))
+		for i, li := range list {
+			twc.wrapPrefixed(prefixes[i], li, indent)
+		}
+	})
 }
 
 // IdxListItem calls IdxList it is simply a more convenient interface
-func (twc TWConf) IdxListItem(indent int, list ...string) {
-	twc.IdxList(list, indent)
+func (twc TWConf) IdxListItem(indent int, list ...string) (int, error) {
+	return twc.IdxList(list, indent)
 }
 
 // NoRptList will print a list of strings with no wrapping. Each list item
 // will have any characters from the start of the string which are common to
 // the preceding list item replaced with spaces
-func (twc TWConf) NoRptList(list []string, indent int) {
-	prev := []rune{}
+func (twc TWConf) NoRptList(list []string, indent int) (int, error) {
+	return twc.captureLimited(indent, func(twc TWConf) {
+		prev := []string{}
 
-	for _, li := range list {
-		twc.Print(strings.Repeat(" ", indent) + twc.ListPrefix)
-		prev = twc.printUniqueStrParts(li, prev)
-	}
+		start := twc.lineStart(indent) + twc.ListPrefix
+		col := strWidth(start)
+
+		for _, li := range list {
+			twc.Print(start)
+			prev = twc.printUniqueStrParts(twc.expandTabs(li, col), prev)
+		}
+	})
 }
 
 // NoRptListItem calls NoRptList it is simply a more convenient interface
-func (twc TWConf) NoRptListItem(indent int, list ...string) {
-	twc.NoRptList(list, indent)
+func (twc TWConf) NoRptListItem(indent int, list ...string) (int, error) {
+	return twc.NoRptList(list, indent)
 }
 
 // IdxNoRptList will print a list of strings with no wrapping. Each
 // list item will be prefixed with an index number and will have any
 // characters from the start of the string which are common to the preceding
 // list item replaced with spaces
-func (twc TWConf) IdxNoRptList(list []string, indent int) {
-	digits := mathutil.Digits(len(list))
-	prev := []rune{}
-
-	for i, li := range list {
-		twc.Print(strings.Repeat(" ", indent))
-		twc.Print(idxListPrefix(twc.ListPrefix, i+1, digits))
-		prev = twc.printUniqueStrParts(li, prev)
-	}
+func (twc TWConf) IdxNoRptList(list []string, indent int) (int, error) {
+	return twc.captureLimited(indent, func(twc TWConf) {
+		prefixes := twc.idxListPrefixes(len(list))
+		prev := []string{}
+
+		for i, li := range list {
+			start := twc.lineStart(indent) + prefixes[i]
+			twc.Print(start)
+			prev = twc.printUniqueStrParts(
+				twc.expandTabs(li, strWidth(start)), prev)
+		}
+	})
 }
 
 // IdxNoRptListItem calls IdxNoRptList it is simply a more convenient
 // interface
-func (twc TWConf) IdxNoRptListItem(indent int, list ...string) {
-	twc.IdxNoRptList(list, indent)
+func (twc TWConf) IdxNoRptListItem(indent int, list ...string) (int, error) {
+	return twc.IdxNoRptList(list, indent)
 }
 
 // NoRptPathList will print a list of strings with no wrapping. Each list
@@ -81,23 +96,28 @@ func (twc TWConf) IdxNoRptListItem(indent int, list ...string) {
 // (except the last) which is the same as the corresponding part of the
 // previous list item replaced with spaces. As soon as any part of the path
 // differs, the remainder is printed as is.
-func (twc TWConf) NoRptPathList(list []string, indent int) {
-	prev := []string{}
+func (twc TWConf) NoRptPathList(list []string, indent int) (int, error) {
+	return twc.captureLimited(indent, func(twc TWConf) {
+		prev := []string{}
 
-	for _, li := range list {
-		twc.Print(strings.Repeat(" ", indent) + twc.ListPrefix)
+		start := twc.lineStart(indent) + twc.ListPrefix
+		col := strWidth(start)
 
-		dir, file := filepath.Split(li)
-		prev = twc.printUniqueDirParts(dir, prev)
+		for _, li := range list {
+			twc.Print(start)
 
-		twc.Print(file + "\n")
-	}
+			dir, file := filepath.Split(twc.expandTabs(li, col))
+			prev = twc.printUniqueDirParts(dir, prev)
+
+			twc.Print(file + "\n")
+		}
+	})
 }
 
 // NoRptPathListItem calls NoRptPathList it is simply a more convenient
 // interface
-func (twc TWConf) NoRptPathListItem(indent int, list ...string) {
-	twc.NoRptPathList(list, indent)
+func (twc TWConf) NoRptPathListItem(indent int, list ...string) (int, error) {
+	return twc.NoRptPathList(list, indent)
 }
 
 // IdxNoRptPathList will print a list of strings with no wrapping. Each list
@@ -106,60 +126,80 @@ func (twc TWConf) NoRptPathListItem(indent int, list ...string) {
 // is the same as the corresponding part of the previous list item replaced
 // with spaces. As soon as any part of the path differs, the remainder is
 // printed as is.
-func (twc TWConf) IdxNoRptPathList(list []string, indent int) {
-	digits := mathutil.Digits(len(list))
-	prev := []string{}
+func (twc TWConf) IdxNoRptPathList(list []string, indent int) (int, error) {
+	return twc.captureLimited(indent, func(twc TWConf) {
+		prefixes := twc.idxListPrefixes(len(list))
+		prev := []string{}
 
-	for i, li := range list {
-		twc.Print(strings.Repeat(" ", indent))
-		twc.Print(idxListPrefix(twc.ListPrefix, i+1, digits))
+		for i, li := range list {
+			start := twc.lineStart(indent) + prefixes[i]
+			twc.Print(start)
 
-		dir, file := filepath.Split(li)
-		prev = twc.printUniqueDirParts(dir, prev)
+			dir, file := filepath.Split(
+				twc.expandTabs(li, strWidth(start)))
+			prev = twc.printUniqueDirParts(dir, prev)
 
-		twc.Print(file + "\n")
-	}
+			twc.Print(file + "\n")
+		}
+	})
 }
 
 // IdxNoRptPathListItem calls IdxNoRptPathList it is simply a more convenient
 // interface
-func (twc TWConf) IdxNoRptPathListItem(indent int, list ...string) {
-	twc.IdxNoRptPathList(list, indent)
+func (twc TWConf) IdxNoRptPathListItem(
+	indent int, list ...string,
+) (int, error) {
+	return twc.IdxNoRptPathList(list, indent)
 }
 
-// idxListPrefix will return a suitable indexed list prefix
-func idxListPrefix(listPfx string, i, digits int) string {
-	return fmt.Sprintf("%s%*d: ", listPfx, digits, i)
+// printUniqueStrParts replaces the leading part of the string that is the
+// same as in prev with spaces filling the same display width. Escape
+// sequences are compared as a whole and take no space; any style they set
+// in the replaced part is restored before the rest of the string. It
+// returns the parts of the string for setting prev for the next call
+func (twc TWConf) printUniqueStrParts(s string, prev []string) []string {
+	parts := strParts(s)
+
+	var (
+		b     strings.Builder
+		style sgrStyle
+	)
+
+	i := 0
+	for ; i < len(parts) && i < len(prev) && parts[i] == prev[i]; i++ {
+		b.WriteString(strings.Repeat(" ", strWidth(parts[i])))
+		style.update(parts[i])
+	}
+
+	if i < len(parts) {
+		b.WriteString(style.open())
+		b.WriteString(strings.Join(parts[i:], ""))
+	}
+
+	twc.Print(b.String() + "\n")
+
+	return parts
 }
 
-// printUniqueStrParts replaces the leading part of the string that is the
-// same as in prev with the equivalent number of spaces. It returns the slice
-// of runes for setting prev for the next call
-func (twc TWConf) printUniqueStrParts(s string, prev []rune) []rune {
-	ra := []rune(s)
-	out := make([]rune, 0, len(s))
-
-	for i, r := range ra {
-		if i >= len(prev) {
-			out = append(out, ra[i:]...)
-			break
-		}
+// strParts splits the string into its runes and escape sequences
+func strParts(s string) []string {
+	parts := make([]string, 0, len(s))
 
-		if r != prev[i] {
-			out = append(out, ra[i:]...)
-			break
+	for i := 0; i < len(s); {
+		n := escSeqLen(s[i:])
+		if n == 0 {
+			_, n = utf8.DecodeRuneInString(s[i:])
 		}
 
-		out = append(out, ' ')
+		parts = append(parts, s[i:i+n])
+		i += n
 	}
 
-	twc.Print(string(out) + "\n")
-
-	return ra
+	return parts
 }
 
 // printUniqueDirParts replaces those leading parts of the directory that are
-// the same as in the prevParts with the equivalent number of spaces. It
+// the same as in the prevParts with spaces filling the same display width. It
 // returns the split directory for setting the prevParts for the next call
 func (twc TWConf) printUniqueDirParts(dir string, prevParts []string) []string {
 	dirParts := []string{}
@@ -177,7 +217,7 @@ func (twc TWConf) printUniqueDirParts(dir string, prevParts []string) []string {
 				break
 			}
 
-			twc.Print(strings.Repeat(" ", len(dp)+len(pathSep)))
+			twc.Print(strings.Repeat(" ", strWidth(dp)+strWidth(pathSep)))
 		}
 	}
 
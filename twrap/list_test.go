@@ -93,7 +93,7 @@ func TestList(t *testing.T) {
 
 	for _, tc := range testCases {
 		for _, l := range []struct {
-			f    func(twrap.TWConf, []string, int)
+			f    func(twrap.TWConf, []string, int) (int, error)
 			name string
 		}{
 			{f: twrap.TWConf.List, name: "-List"},
@@ -110,3 +110,45 @@ func TestList(t *testing.T) {
 		}
 	}
 }
+
+// TestIdxListZeroTWConf checks that a TWConf which has not been made by
+// NewTWConf, and so has none of the index settings, prints the same
+// indexes as before they could be changed
+func TestIdxListZeroTWConf(t *testing.T) {
+	var b bytes.Buffer
+
+	twc := twrap.TWConf{W: &b, TargetLineLen: 40}
+
+	twc.IdxList(
+		[]string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}, 0)
+	testhelper.DiffString(t, "IdxList", "zero value TWConf output",
+		b.String(),
+		" 1: a\n 2: b\n 3: c\n 4: d\n 5: e\n 6: f\n 7: g\n 8: h\n 9: i\n10: j\n")
+
+	b.Reset()
+	twc.IdxNoRptList([]string{"ab", "ac"}, 2)
+	testhelper.DiffString(t, "IdxNoRptList", "zero value TWConf output",
+		b.String(), "  1: ab\n  2:  c\n")
+}
+
+func TestNoRptListEscSeqs(t *testing.T) {
+	twc := twrap.NewTWConfOrPanic()
+
+	testhelper.DiffStringSlice(t, "NoRptList", "list lines",
+		twc.NoRptListLines(
+			[]string{"\x1b[1mabc\x1b[0m", "\x1b[1mabd\x1b[0m", "\x1b[1mabd"},
+			0),
+		[]string{
+			"- \x1b[1mabc\x1b[0m",
+			"-   \x1b[1md\x1b[0m",
+			"-    ",
+		})
+	testhelper.DiffStringSlice(t, "IdxNoRptList", "list lines",
+		twc.IdxNoRptListLines(
+			[]string{"\x1b[31mab\x1b[1mc\x1b[0m", "\x1b[31mab\x1b[1md\x1b[0m"},
+			0),
+		[]string{
+			"- 1: \x1b[31mab\x1b[1mc\x1b[0m",
+			"- 2:   \x1b[31m\x1b[1md\x1b[0m",
+		})
+}
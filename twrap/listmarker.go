@@ -0,0 +1,79 @@
+package twrap
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ListMarkerFunc recognises the marker at the start of an item in a list.
+// It is passed the start of a paragraph and returns the length in bytes of
+// the list marker, including any spaces following it, or zero if the
+// paragraph doesn't start with a list marker. The lines of a list item
+// after the first are indented by the width of the marker so that they
+// line up with the text following it.
+type ListMarkerFunc func(para string) int
+
+// BulletMarker returns a ListMarkerFunc which recognises paragraphs starting
+// with any of the bullets. Each bullet should include the space following
+// it.
+func BulletMarker(bullets ...string) ListMarkerFunc {
+	return func(para string) int {
+		for _, b := range bullets {
+			if b != "" && strings.HasPrefix(para, b) {
+				return len(b)
+			}
+		}
+
+		return 0
+	}
+}
+
+// RegexpMarker returns a ListMarkerFunc which recognises paragraphs
+// starting with a match for the regular expression. A match anywhere other
+// than at the start of the paragraph is ignored.
+func RegexpMarker(re *regexp.Regexp) ListMarkerFunc {
+	return func(para string) int {
+		loc := re.FindStringIndex(para)
+		if loc == nil || loc[0] != 0 {
+			return 0
+		}
+
+		return loc[1]
+	}
+}
+
+var numberedMarkerRE = regexp.MustCompile(`^(?:` +
+	`[0-9]+[.)]|` +
+	`[a-zA-Z][.)]|` +
+	`[ivxlcdm]+[.)]|` +
+	`[IVXLCDM]+[.)]|` +
+	`\((?:[0-9]+|[a-zA-Z]|[ivxlcdm]+|[IVXLCDM]+)\)` +
+	`)[ \t]+`)
+
+// NumberedMarker is a ListMarkerFunc which recognises numbered list items.
+// These start with a number, a letter or a roman numeral followed by '.' or
+// ')' or else enclosed in parentheses. The marker must be followed by at
+// least one space or tab. For instance "1. ", "a) " or "(iv) ".
+func NumberedMarker(para string) int {
+	return RegexpMarker(numberedMarkerRE)(para)
+}
+
+// dfltListMarkers are the list markers used if none have been set
+var dfltListMarkers = []ListMarkerFunc{BulletMarker("- ", "* ", "+ ")}
+
+// listMarkerLen returns the length of the list marker at the start of the
+// paragraph or zero if it doesn't start with a list marker
+func (twc TWConf) listMarkerLen(para string) int {
+	markers := twc.ListMarkers
+	if markers == nil {
+		markers = dfltListMarkers
+	}
+
+	for _, lm := range markers {
+		if n := lm(para); n > 0 {
+			return n
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,237 @@
+package twrap_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+	"github.com/nickwells/twrap.mod/twrap"
+)
+
+func TestNumberedMarker(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		para   string
+		expLen int
+	}{
+		{ID: testhelper.MkID("number, dot"), para: "1. text", expLen: 3},
+		{ID: testhelper.MkID("number, paren"), para: "12) text", expLen: 4},
+		{ID: testhelper.MkID("letter, paren"), para: "a) text", expLen: 3},
+		{ID: testhelper.MkID("roman, parens"), para: "(iv) text", expLen: 5},
+		{ID: testhelper.MkID("upper roman"), para: "XII.  text", expLen: 6},
+		{ID: testhelper.MkID("no space"), para: "1.5 units"},
+		{ID: testhelper.MkID("word"), para: "word. text"},
+		{ID: testhelper.MkID("not at start"), para: "see 1. text"},
+	}
+
+	for _, tc := range testCases {
+		testhelper.DiffInt(t, tc.IDStr(), "marker length",
+			twrap.NumberedMarker(tc.para), tc.expLen)
+	}
+}
+
+func TestListMarkers(t *testing.T) {
+	const text = "1. the first item which is long enough to wrap\n" +
+		"(iv) the fourth item which is long enough to wrap\n" +
+		"- a bulleted item which is long enough to wrap\n" +
+		"=> an arrowed item which is long enough to wrap"
+
+	testCases := []struct {
+		testhelper.ID
+		opts     []twrap.TWConfOptFunc
+		expLines []string
+	}{
+		{
+			ID: testhelper.MkID("default markers"),
+			expLines: []string{
+				"1. the first item which",
+				"is long enough to wrap",
+				"(iv) the fourth item",
+				"which is long enough to",
+				"wrap",
+				"- a bulleted item which",
+				"  is long enough to wrap",
+				"=> an arrowed item which",
+				"is long enough to wrap",
+			},
+		},
+		{
+			ID: testhelper.MkID("numbered and custom markers"),
+			opts: []twrap.TWConfOptFunc{
+				twrap.SetListMarkers(
+					twrap.NumberedMarker,
+					twrap.BulletMarker("- ", "=> ")),
+			},
+			expLines: []string{
+				"1. the first item which",
+				"   is long enough to",
+				"   wrap",
+				"(iv) the fourth item",
+				"     which is long",
+				"     enough to wrap",
+				"- a bulleted item which",
+				"  is long enough to wrap",
+				"=> an arrowed item which",
+				"   is long enough to",
+				"   wrap",
+			},
+		},
+		{
+			ID: testhelper.MkID("regexp marker, justified"),
+			opts: []twrap.TWConfOptFunc{
+				twrap.SetListMarkers(
+					twrap.RegexpMarker(regexp.MustCompile(`\(iv\) +`))),
+				twrap.SetAlignment(twrap.AlignJustify),
+			},
+			expLines: []string{
+				"1.  the first item which",
+				"is long enough to wrap",
+				"(iv) the   fourth   item",
+				"     which    is    long",
+				"     enough to wrap",
+				"-  a bulleted item which",
+				"is long enough to wrap",
+				"=> an arrowed item which",
+				"is long enough to wrap",
+			},
+		},
+		{
+			ID:   testhelper.MkID("no markers"),
+			opts: []twrap.TWConfOptFunc{twrap.SetListMarkers()},
+			expLines: []string{
+				"1. the first item which",
+				"is long enough to wrap",
+				"(iv) the fourth item",
+				"which is long enough to",
+				"wrap",
+				"- a bulleted item which",
+				"is long enough to wrap",
+				"=> an arrowed item which",
+				"is long enough to wrap",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		for _, bs := range []twrap.BreakStrategy{
+			twrap.BreakGreedy, twrap.BreakOptimal,
+		} {
+			opts := append([]twrap.TWConfOptFunc{
+				twrap.SetMinChars(10),
+				twrap.SetTargetLineLen(24),
+				twrap.SetBreakStrategy(bs),
+			}, tc.opts...)
+			twc := twrap.NewTWConfOrPanic(opts...)
+
+			testhelper.DiffStringSlice(t, tc.IDStr(), "wrapped lines",
+				twc.WrapLines(text, 0), tc.expLines)
+		}
+	}
+}
+
+func TestParaBreak(t *testing.T) {
+	const text = "the first paragraph\nhas two lines\n\n" +
+		"the second\r\nparagraph\n \n\nand the third"
+
+	twc := twrap.NewTWConfOrPanic(
+		twrap.SetMinChars(10),
+		twrap.SetTargetLineLen(30),
+		twrap.SetParaBreak(regexp.MustCompile(`\n[ \t]*\n\s*`)))
+
+	expLines := []string{
+		"  the first paragraph has two",
+		"  lines",
+		"  the second paragraph",
+		"  and the third",
+	}
+
+	testhelper.DiffStringSlice(t, "ParaBreak", "wrapped lines",
+		twc.WrapLines(text, 2), expLines)
+
+	var b strings.Builder
+
+	twc.W = &b
+	w := twrap.NewWriter(*twc, 2)
+
+	for _, c := range []byte(text) {
+		_, _ = w.Write([]byte{c})
+	}
+
+	_ = w.Close()
+	testhelper.DiffString(t, "ParaBreak", "Writer output",
+		b.String(), strings.Join(expLines, "\n")+"\n")
+
+	_, err := twrap.NewTWConf(twrap.SetParaBreak(nil))
+	testhelper.CheckExpErrWithID(t, "nil ParaBreak", err,
+		testhelper.MkExpErr("the paragraph break must not be nil"))
+}
+
+func TestListIndentMinChars(t *testing.T) {
+	const text = "- the list item which is long enough to wrap"
+
+	// the indents differ but both lines are held to the MinCharsToPrint so
+	// they have the same length and the list indent still applies
+	twc := twrap.NewTWConfOrPanic(
+		twrap.SetMinChars(16),
+		twrap.SetTargetLineLen(20))
+
+	testhelper.DiffStringSlice(t, "MinCharsToPrint", "wrapped lines",
+		twc.Wrap3IndentLines(text, 6, 6, 8),
+		[]string{
+			"      - the list item",
+			"          which is long",
+			"          enough to wrap",
+		})
+}
+
+func TestListMarkerAloneOnLine(t *testing.T) {
+	long := "ccc" + strings.Repeat("e", 35) + "*"
+
+	testCases := []struct {
+		testhelper.ID
+		text     string
+		lineLen  int
+		indents  [3]int
+		expLines []string
+	}{
+		{
+			ID:      testhelper.MkID("bullet then a long word"),
+			text:    "* " + long + " xbb",
+			lineLen: 33,
+			indents: [3]int{1, 2, 1},
+			expLines: []string{
+				" *",
+				"   " + long,
+				"   xbb",
+			},
+		},
+		{
+			ID:      testhelper.MkID("bullet then a long URL"),
+			text:    "- https://example.com/a/very/long/path/x and then more",
+			lineLen: 30,
+			indents: [3]int{2, 2, 2},
+			expLines: []string{
+				"  -",
+				"    https://example.com/a/very/long/path/x",
+				"    and then more",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		for _, bs := range []twrap.BreakStrategy{
+			twrap.BreakGreedy, twrap.BreakOptimal,
+		} {
+			twc := twrap.NewTWConfOrPanic(
+				twrap.SetMinChars(10),
+				twrap.SetTargetLineLen(tc.lineLen),
+				twrap.SetBreakStrategy(bs))
+
+			testhelper.DiffStringSlice(t, tc.IDStr(), "wrapped lines",
+				twc.Wrap3IndentLines(tc.text,
+					tc.indents[0], tc.indents[1], tc.indents[2]),
+				tc.expLines)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package twrap
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// DfltTruncMarker is the default marker printed in place of the lines
+// beyond the MaxLines limit
+const DfltTruncMarker = "… (%d more)"
+
+// lineLimiter is an io.Writer which passes at most max lines to the
+// underlying writer. The last line allowed is held back until it is known
+// whether there are more lines to follow; if there are it is replaced by a
+// marker.
+type lineLimiter struct {
+	w       io.Writer
+	max     int
+	lines   int    // the number of lines seen so far
+	held    []byte // the last line allowed, held back
+	partial bool   // true if the last line seen has no newline yet
+}
+
+// Write passes the lines to the underlying writer until the limit is
+// reached. It always reports that all the bytes were written; the
+// underlying writer is expected to record any errors.
+func (ll *lineLimiter) Write(p []byte) (int, error) {
+	n := len(p)
+
+	for len(p) > 0 {
+		line := p
+		if i := bytes.IndexByte(p, '\n'); i >= 0 {
+			line = p[:i+1]
+		}
+
+		p = p[len(line):]
+
+		if !ll.partial {
+			ll.lines++
+		}
+
+		ll.partial = line[len(line)-1] != '\n'
+
+		switch {
+		case ll.lines < ll.max:
+			_, _ = ll.w.Write(line)
+		case ll.lines == ll.max:
+			ll.held = append(ll.held, line...)
+		}
+	}
+
+	return n, nil
+}
+
+// finish prints the held line if there were no more lines or else the
+// truncation marker, prefixed by the LineLeader and the indent
+func (ll *lineLimiter) finish(twc TWConf, indent int) {
+	if ll.lines <= ll.max {
+		_, _ = ll.w.Write(ll.held)
+		return
+	}
+
+	twc.W = ll.w
+	twc.Print(twc.truncMarkerLine(ll.lines-ll.max+1, indent))
+}
+
+// truncMarkerLine returns the line to be printed in place of the hidden
+// lines. The marker is made to fit within the TargetLineLen, first by
+// dropping the indent and then by shortening the marker.
+func (twc TWConf) truncMarkerLine(hidden, indent int) string {
+	marker := strings.ReplaceAll(twc.TruncMarker, "%d", strconv.Itoa(hidden))
+
+	prefix := twc.lineStart(indent)
+	if strWidth(prefix)+strWidth(marker) > twc.TargetLineLen {
+		prefix = twc.LineLeader
+	}
+
+	marker, _ = splitAtWidth(marker,
+		max(twc.TargetLineLen-strWidth(prefix), 1))
+
+	return prefix + marker + "\n"
+}
+
+// captureLimited calls capture but, if MaxLines is set, any lines beyond
+// the limit are replaced by the TruncMarker. The indent is used for the
+// marker line.
+func (twc TWConf) captureLimited(indent int, f func(TWConf)) (int, error) {
+	return twc.capture(func(twc TWConf) {
+		if twc.MaxLines <= 0 {
+			f(twc)
+			return
+		}
+
+		ll := &lineLimiter{w: twc.W, max: twc.MaxLines}
+		twc.W = ll
+		f(twc)
+		ll.finish(twc, indent)
+	})
+}
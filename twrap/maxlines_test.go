@@ -0,0 +1,147 @@
+package twrap_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+	"github.com/nickwells/twrap.mod/twrap"
+)
+
+func TestMaxLines(t *testing.T) {
+	const text = "one two three four five six seven eight nine ten"
+
+	testCases := []struct {
+		testhelper.ID
+		opts     []twrap.TWConfOptFunc
+		indent   int
+		expLines []string
+	}{
+		{
+			ID: testhelper.MkID("no limit"),
+			expLines: []string{
+				"one two three",
+				"four five six",
+				"seven eight nine",
+				"ten",
+			},
+		},
+		{
+			ID:   testhelper.MkID("limit not reached"),
+			opts: []twrap.TWConfOptFunc{twrap.SetMaxLines(4)},
+			expLines: []string{
+				"one two three",
+				"four five six",
+				"seven eight nine",
+				"ten",
+			},
+		},
+		{
+			ID:   testhelper.MkID("limit exceeded"),
+			opts: []twrap.TWConfOptFunc{twrap.SetMaxLines(3)},
+			expLines: []string{
+				"one two three",
+				"four five six",
+				"… (2 more)",
+			},
+		},
+		{
+			ID:     testhelper.MkID("limit exceeded, indented"),
+			opts:   []twrap.TWConfOptFunc{twrap.SetMaxLines(2)},
+			indent: 1,
+			expLines: []string{
+				" one two three",
+				" … (3 more)",
+			},
+		},
+		{
+			ID: testhelper.MkID("limit exceeded, marker too long"),
+			opts: []twrap.TWConfOptFunc{
+				twrap.SetMaxLines(1),
+				twrap.SetTruncMarker("[%d lines were not shown]"),
+			},
+			indent: 1,
+			expLines: []string{
+				"[4 lines were not",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		opts := append([]twrap.TWConfOptFunc{
+			twrap.SetMinChars(5),
+			twrap.SetTargetLineLen(17),
+		}, tc.opts...)
+		twc := twrap.NewTWConfOrPanic(opts...)
+
+		testhelper.DiffStringSlice(t, tc.IDStr(), "wrapped lines",
+			twc.WrapLines(text, tc.indent), tc.expLines)
+	}
+}
+
+func TestMaxLinesList(t *testing.T) {
+	twc := twrap.NewTWConfOrPanic(
+		twrap.SetMinChars(5),
+		twrap.SetTargetLineLen(20),
+		twrap.SetMaxLines(3))
+
+	list := []string{"a", "b which wraps onto the next line", "c", "d"}
+
+	testhelper.DiffStringSlice(t, "List", "list lines",
+		twc.ListLines(list, 2),
+		[]string{
+			"  - a",
+			"  - b which wraps",
+			"  … (4 more)",
+		})
+	testhelper.DiffStringSlice(t, "IdxList", "list lines",
+		twc.IdxListLines(list[:3], 0),
+		[]string{
+			"- 1: a",
+			"- 2: b which wraps",
+			"… (3 more)",
+		})
+	testhelper.DiffStringSlice(t, "NoRptList", "list lines",
+		twc.NoRptListLines(list[:3], 0),
+		[]string{
+			"- a",
+			"- b which wraps onto the next line",
+			"- c",
+		})
+	testhelper.DiffStringSlice(t, "IdxNoRptPathList", "list lines",
+		twc.IdxNoRptPathListLines([]string{"a/b", "a/c", "a/d", "a/e"}, 0),
+		[]string{
+			"- 1: a/b",
+			"- 2:   c",
+			"… (2 more)",
+		})
+}
+
+func TestMaxLinesCommandAndWriter(t *testing.T) {
+	twc := twrap.NewTWConfOrPanic(
+		twrap.SetMinChars(5),
+		twrap.SetTargetLineLen(20),
+		twrap.SetMaxLines(2))
+
+	testhelper.DiffStringSlice(t, "WrapCommand", "command lines",
+		twc.WrapCommandLines("ls -l -a /some/dir /another/dir /etc", 0),
+		[]string{
+			"ls -l -a /some/dir",
+			"… (2 more)",
+		})
+
+	var b strings.Builder
+
+	twc.W = &b
+	w := twrap.NewWriter(*twc, 2)
+
+	for _, s := range []string{
+		"one two three ", "four five six ", "seven eight nine ten eleven",
+	} {
+		_, _ = w.Write([]byte(s))
+	}
+
+	_ = w.Close()
+	testhelper.DiffString(t, "Writer", "written text", b.String(),
+		"  one two three four\n  … (3 more)\n")
+}
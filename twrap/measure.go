@@ -0,0 +1,141 @@
+package twrap
+
+import (
+	"bytes"
+	"strings"
+)
+
+// ParaMeasurement records the size of a paragraph as it would be printed
+type ParaMeasurement struct {
+	FirstLine int // the index of the first line of the paragraph
+	Lines     int // the number of lines printed for the paragraph
+	MaxWidth  int // the width of the widest line, including any indent
+}
+
+// Measurement records the size of some text as it would be printed
+type Measurement struct {
+	Lines    int // the number of lines printed
+	MaxWidth int // the width of the widest line, including any indent
+	Paras    []ParaMeasurement
+}
+
+// Measure returns the size of the text as it would be printed by Wrap
+func (twc TWConf) Measure(text string, indent int) Measurement {
+	return twc.Measure3Indent(text, indent, indent, indent)
+}
+
+// Measure3Indent returns the size of the text as it would be printed by
+// Wrap3Indent. Nothing is printed. The same rules are used to wrap the
+// text as when it is printed so the measurements will always match the
+// printed text.
+//
+// There is an entry in Paras for each paragraph that the text is split
+// into, even if nothing is printed for it. Any blank line printed to
+// separate paragraphs (see SetBlankLineParas) is not counted as part of
+// either paragraph. If MaxLines is set then only the lines actually
+// printed are counted so the paragraphs beyond the limit will have no
+// lines; the truncation marker is only counted in the totals.
+func (twc TWConf) Measure3Indent(
+	text string,
+	line1Indent, paraLine1Indent, line2Indent int,
+) Measurement {
+	m := &measurer{}
+
+	if text != "" {
+		mtwc := twc
+		mtwc.W = m
+
+		w := newWrapper(mtwc, line1Indent, paraLine1Indent, line2Indent)
+		w.measurer = m
+		w.wrapParas(text)
+	}
+
+	m.endLine()
+
+	widths, paras := m.widths, m.paras
+
+	if twc.MaxLines > 0 && len(widths) > twc.MaxLines {
+		shown := twc.MaxLines - 1
+		marker := strings.TrimSuffix(
+			twc.truncMarkerLine(len(widths)-shown, line1Indent), "\n")
+		widths = append(widths[:shown:shown], strWidth(marker))
+
+		for i, pm := range paras {
+			paras[i].FirstLine = min(pm.FirstLine, shown)
+			paras[i].Lines = max(min(pm.Lines, shown-pm.FirstLine), 0)
+		}
+	}
+
+	for i, pm := range paras {
+		paras[i].MaxWidth = maxWidth(widths[pm.FirstLine:][:pm.Lines])
+	}
+
+	return Measurement{
+		Lines:    len(widths),
+		MaxWidth: maxWidth(widths),
+		Paras:    paras,
+	}
+}
+
+// maxWidth returns the greatest of the widths or zero if there are none
+func maxWidth(widths []int) int {
+	mw := 0
+	for _, w := range widths {
+		mw = max(mw, w)
+	}
+
+	return mw
+}
+
+// measurer is an io.Writer which records the widths of the lines written
+// to it. The wrapper tells it where each paragraph starts and ends.
+type measurer struct {
+	line   []byte // the current, incomplete, line
+	widths []int
+
+	paraStart int
+	paras     []ParaMeasurement
+}
+
+// Write records the lines written. It never fails.
+func (m *measurer) Write(p []byte) (int, error) {
+	n := len(p)
+
+	for {
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			m.line = append(m.line, p...)
+			break
+		}
+
+		m.line = append(m.line, p[:i]...)
+		m.widths = append(m.widths, strWidth(string(m.line)))
+		m.line = m.line[:0]
+
+		p = p[i+1:]
+	}
+
+	return n, nil
+}
+
+// endLine records the current line, if it is incomplete
+func (m *measurer) endLine() {
+	if len(m.line) > 0 {
+		m.widths = append(m.widths, strWidth(string(m.line)))
+		m.line = m.line[:0]
+	}
+}
+
+// startPara records that any following lines are part of a new paragraph
+func (m *measurer) startPara() {
+	m.paraStart = len(m.widths)
+}
+
+// endPara records the lines of the paragraph
+func (m *measurer) endPara() {
+	m.paras = append(m.paras, ParaMeasurement{
+		FirstLine: m.paraStart,
+		Lines:     len(m.widths) - m.paraStart,
+	})
+	m.startPara()
+}
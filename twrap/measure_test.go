@@ -0,0 +1,86 @@
+package twrap_test
+
+import (
+	"testing"
+
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+	"github.com/nickwells/twrap.mod/twrap"
+)
+
+func TestMeasure(t *testing.T) {
+	const text = "one two three four five six seven\n\nx\n- eight nine ten"
+
+	testCases := []struct {
+		testhelper.ID
+		opts    []twrap.TWConfOptFunc
+		indent  int
+		text    string
+		expMeas twrap.Measurement
+	}{
+		{
+			ID: testhelper.MkID("empty"),
+		},
+		{
+			ID:     testhelper.MkID("paragraphs"),
+			indent: 2,
+			text:   text,
+			expMeas: twrap.Measurement{
+				Lines:    7,
+				MaxWidth: 15,
+				Paras: []twrap.ParaMeasurement{
+					{FirstLine: 0, Lines: 3, MaxWidth: 15},
+					{FirstLine: 3, Lines: 1, MaxWidth: 0},
+					{FirstLine: 4, Lines: 1, MaxWidth: 3},
+					{FirstLine: 5, Lines: 2, MaxWidth: 14},
+				},
+			},
+		},
+		{
+			ID:   testhelper.MkID("blank line paragraphs"),
+			opts: []twrap.TWConfOptFunc{twrap.SetBlankLineParas(true)},
+			text: text,
+			expMeas: twrap.Measurement{
+				Lines:    6,
+				MaxWidth: 14,
+				Paras: []twrap.ParaMeasurement{
+					{FirstLine: 0, Lines: 3, MaxWidth: 13},
+					{FirstLine: 4, Lines: 2, MaxWidth: 14},
+				},
+			},
+		},
+		{
+			ID:   testhelper.MkID("max lines"),
+			opts: []twrap.TWConfOptFunc{twrap.SetMaxLines(4)},
+			text: text,
+			expMeas: twrap.Measurement{
+				Lines:    4,
+				MaxWidth: 13,
+				Paras: []twrap.ParaMeasurement{
+					{FirstLine: 0, Lines: 3, MaxWidth: 13},
+					{FirstLine: 3, Lines: 0, MaxWidth: 0},
+					{FirstLine: 3, Lines: 0, MaxWidth: 0},
+					{FirstLine: 3, Lines: 0, MaxWidth: 0},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		opts := append([]twrap.TWConfOptFunc{
+			twrap.SetMinChars(5),
+			twrap.SetTargetLineLen(16),
+		}, tc.opts...)
+		twc := twrap.NewTWConfOrPanic(opts...)
+
+		m := twc.Measure(tc.text, tc.indent)
+		testhelper.DiffInt(t, tc.IDStr(), "Lines", m.Lines, tc.expMeas.Lines)
+		testhelper.DiffInt(t, tc.IDStr(), "MaxWidth",
+			m.MaxWidth, tc.expMeas.MaxWidth)
+		testhelper.DiffSlice(t, tc.IDStr(), "Paras",
+			m.Paras, tc.expMeas.Paras)
+
+		lines := twc.WrapLines(tc.text, tc.indent)
+		testhelper.DiffInt(t, tc.IDStr(), "printed lines",
+			len(lines), m.Lines)
+	}
+}
@@ -0,0 +1,154 @@
+package twrap
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// OverflowPolicy describes what is done with a word which is too long to
+// fit on a line by itself
+type OverflowPolicy int
+
+// These are the available overflow policies. Note that they only apply to
+// the text which is wrapped; the NoRpt... lists are not wrapped and so
+// long words are always printed in full.
+//
+// OverflowAllow, the default, prints the word in full, regardless of
+// length, so the line will be longer than the target.
+//
+// OverflowSplit splits the word at the maximum line length. The SplitMarker
+// is printed at the end of each part but the last and its width is
+// included in the line length.
+//
+// OverflowBreakChars splits the word as for OverflowSplit but will split
+// it after the last of the OverflowBreakChars that fits on the line if
+// there is one.
+//
+// OverflowTruncate truncates the word so that it fits on the line and
+// ends it with the Ellipsis.
+const (
+	OverflowAllow OverflowPolicy = iota
+	OverflowSplit
+	OverflowBreakChars
+	OverflowTruncate
+)
+
+// These are the default values for the overflow settings
+const (
+	DfltOverflowBreakChars = "/-_."
+	DfltEllipsis           = "…"
+)
+
+// IsValid returns true if the OverflowPolicy is one of the known values
+func (op OverflowPolicy) IsValid() bool {
+	return op >= OverflowAllow && op <= OverflowTruncate
+}
+
+// splitAtWidth returns the longest leading part of s that will fit in the
+// given width and the remainder. Escape sequences are never split and any
+// zero-width characters following the last character that fits are kept
+// with it. The head will always hold at least one visible character
+// (unless s has none) even if it doesn't fit.
+func splitAtWidth(s string, width int) (head, tail string) {
+	used := 0
+	seenVisible := false
+
+	for i := 0; i < len(s); {
+		if n := escSeqLen(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+
+		r, n := utf8.DecodeRuneInString(s[i:])
+
+		rw := runeWidth(r)
+		if rw > 0 {
+			if used+rw > width && seenVisible {
+				return s[:i], s[i:]
+			}
+
+			seenVisible = true
+		}
+
+		used += rw
+		i += n
+	}
+
+	return s, ""
+}
+
+// lastBreakCharEnd returns the offset just after the last of the break
+// chars in s which is not part of an escape sequence. It returns -1 if
+// there is no such break char.
+func lastBreakCharEnd(s, breakChars string) int {
+	end := -1
+
+	for i := 0; i < len(s); {
+		if n := escSeqLen(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+
+		r, n := utf8.DecodeRuneInString(s[i:])
+		i += n
+
+		if strings.ContainsRune(breakChars, r) {
+			end = i
+		}
+	}
+
+	return end
+}
+
+// escSeqs returns the escape sequences in the text
+func escSeqs(s string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		if n := escSeqLen(s[i:]); n > 0 {
+			b.WriteString(s[i : i+n])
+			i += n - 1
+		}
+	}
+
+	return b.String()
+}
+
+// splitWord splits the word according to the OverflowPolicy so that the
+// head fits in the given width. If the word is truncated then the tail is
+// empty and any escape sequences in the part removed are kept in the head
+// so that styles are still properly closed.
+func (twc TWConf) splitWord(lw lineWord, width int) (head, tail lineWord) {
+	var h, t string
+
+	switch twc.Overflow {
+	case OverflowTruncate:
+		h, t = splitAtWidth(lw.word, width-strWidth(twc.Ellipsis))
+		h += twc.Ellipsis + escSeqs(t)
+		t = ""
+	case OverflowBreakChars:
+		h, t = splitAtWidth(lw.word, width-strWidth(twc.SplitMarker))
+		if i := lastBreakCharEnd(h, twc.OverflowBreakChars); i > 0 {
+			h, t = lw.word[:i], lw.word[i:]
+		}
+
+		h += twc.SplitMarker
+	default:
+		h, t = splitAtWidth(lw.word, width-strWidth(twc.SplitMarker))
+		h += twc.SplitMarker
+	}
+
+	head = lineWord{
+		spaces:    lw.spaces,
+		spacesLen: lw.spacesLen,
+		word:      h,
+		wordLen:   strWidth(h),
+	}
+	tail = lineWord{
+		word:          t,
+		wordLen:       strWidth(t),
+		mustStartLine: true,
+	}
+
+	return head, tail
+}
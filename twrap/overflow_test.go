@@ -0,0 +1,172 @@
+package twrap_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+	"github.com/nickwells/twrap.mod/twrap"
+)
+
+func TestOverflow(t *testing.T) {
+	const text = "see https://example.com/a/very/long/path/name ok"
+
+	testCases := []struct {
+		testhelper.ID
+		opts     []twrap.TWConfOptFunc
+		indent   int
+		text     string
+		expLines []string
+	}{
+		{
+			ID:   testhelper.MkID("allow"),
+			text: text,
+			expLines: []string{
+				"see",
+				"https://example.com/a/very/long/path/name",
+				"ok",
+			},
+		},
+		{
+			ID:   testhelper.MkID("split"),
+			opts: []twrap.TWConfOptFunc{twrap.SetOverflow(twrap.OverflowSplit)},
+			text: text,
+			expLines: []string{
+				"see",
+				"https://example.com/",
+				"a/very/long/path/nam",
+				"e ok",
+			},
+		},
+		{
+			ID: testhelper.MkID("split - marker, indent"),
+			opts: []twrap.TWConfOptFunc{
+				twrap.SetOverflow(twrap.OverflowSplit),
+				twrap.SetSplitMarker("↩"),
+			},
+			indent: 2,
+			text:   text,
+			expLines: []string{
+				"  see",
+				"  https://example.c↩",
+				"  om/a/very/long/pa↩",
+				"  th/name ok",
+			},
+		},
+		{
+			ID: testhelper.MkID("split - leading spaces"),
+			opts: []twrap.TWConfOptFunc{
+				twrap.SetOverflow(twrap.OverflowSplit),
+			},
+			text: "    abcdefghijklmnopqrstuvwxyz",
+			expLines: []string{
+				"    abcdefghijklmnop",
+				"qrstuvwxyz",
+			},
+		},
+		{
+			ID: testhelper.MkID("break chars"),
+			opts: []twrap.TWConfOptFunc{
+				twrap.SetOverflow(twrap.OverflowBreakChars),
+			},
+			text: text,
+			expLines: []string{
+				"see",
+				"https://example.com/",
+				"a/very/long/path/",
+				"name ok",
+			},
+		},
+		{
+			ID: testhelper.MkID("break chars - none found"),
+			opts: []twrap.TWConfOptFunc{
+				twrap.SetOverflow(twrap.OverflowBreakChars),
+				twrap.SetOverflowBreakChars("_"),
+			},
+			text: "aaa bbb-ccc-ddd-eee-fff-ggg",
+			expLines: []string{
+				"aaa",
+				"bbb-ccc-ddd-eee-fff-",
+				"ggg",
+			},
+		},
+		{
+			ID: testhelper.MkID("truncate"),
+			opts: []twrap.TWConfOptFunc{
+				twrap.SetOverflow(twrap.OverflowTruncate),
+			},
+			text: text,
+			expLines: []string{
+				"see",
+				"https://example.com…",
+				"ok",
+			},
+		},
+		{
+			ID: testhelper.MkID("truncate - styled"),
+			opts: []twrap.TWConfOptFunc{
+				twrap.SetOverflow(twrap.OverflowTruncate),
+				twrap.SetEllipsis("..."),
+			},
+			text: "\x1b[1mabcdefghijklmnopqrstuvwxyz\x1b[0m end",
+			expLines: []string{
+				"\x1b[1mabcdefghijklmnopq...\x1b[0m",
+				"end",
+			},
+		},
+		{
+			ID: testhelper.MkID("split - optimal breaks"),
+			opts: []twrap.TWConfOptFunc{
+				twrap.SetOverflow(twrap.OverflowSplit),
+				twrap.SetBreakStrategy(twrap.BreakOptimal),
+			},
+			text: text,
+			expLines: []string{
+				"see",
+				"https://example.com/",
+				"a/very/long/path/nam",
+				"e ok",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		opts := append([]twrap.TWConfOptFunc{
+			twrap.SetMinChars(10),
+			twrap.SetTargetLineLen(20),
+		}, tc.opts...)
+		twc := twrap.NewTWConfOrPanic(opts...)
+
+		testhelper.DiffStringSlice(t, tc.IDStr(), "wrapped lines",
+			twc.WrapLines(tc.text, tc.indent), tc.expLines)
+	}
+}
+
+func TestOverflowListItem(t *testing.T) {
+	texts := []string{
+		"- " + strings.Repeat("a", 49) + " bb",
+		"* xx " + strings.Repeat("b", 30) + " " + strings.Repeat("c", 41),
+		"1. " + strings.Repeat("d", 45),
+	}
+
+	for _, text := range texts {
+		for _, bs := range []twrap.BreakStrategy{
+			twrap.BreakGreedy, twrap.BreakOptimal,
+		} {
+			twc := twrap.NewTWConfOrPanic(
+				twrap.SetMinChars(10),
+				twrap.SetTargetLineLen(20),
+				twrap.SetOverflow(twrap.OverflowSplit),
+				twrap.SetBreakStrategy(bs),
+				twrap.SetListMarkers(
+					twrap.NumberedMarker, twrap.BulletMarker("- ", "* ")))
+
+			for i, line := range twc.WrapLines(text, 0) {
+				if len(line) > 20 {
+					t.Errorf("%q, strategy %d: line %d is too long: %q",
+						text, bs, i, line)
+				}
+			}
+		}
+	}
+}
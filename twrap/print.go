@@ -1,6 +1,9 @@
 package twrap
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+)
 
 // Print calls fmt.Fprint passing the TWConf writer
 func (twc TWConf) Print(a ...any) (n int, err error) {
@@ -16,3 +19,37 @@ func (twc TWConf) Println(a ...any) (n int, err error) {
 func (twc TWConf) Printf(format string, a ...any) (n int, err error) {
 	return fmt.Fprintf(twc.W, format, a...)
 }
+
+// errWriter wraps an io.Writer, counting the bytes written and recording the
+// first error. Once an error has been seen nothing further is written and
+// every subsequent Write returns the same error.
+type errWriter struct {
+	w   io.Writer
+	n   int
+	err error
+}
+
+// Write writes the bytes to the underlying writer unless a previous write
+// has failed
+func (ew *errWriter) Write(b []byte) (int, error) {
+	if ew.err != nil {
+		return 0, ew.err
+	}
+
+	n, err := ew.w.Write(b)
+	ew.n += n
+	ew.err = err
+
+	return n, err
+}
+
+// capture calls the function with a copy of the TWConf whose writer has
+// been wrapped by an errWriter. It returns the number of bytes written and
+// the first error encountered.
+func (twc TWConf) capture(f func(TWConf)) (int, error) {
+	ew := &errWriter{w: twc.W}
+	twc.W = ew
+	f(twc)
+
+	return ew.n, ew.err
+}
@@ -0,0 +1,156 @@
+package twrap
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+)
+
+// ResizingTWConf provides TWConfs whose TargetLineLen follows the width of
+// a terminal. It listens for the signal sent when the terminal is resized
+// (SIGWINCH) and records the new width; each subsequent call to the TWConf
+// method will return a TWConf using it. It is safe to use from multiple
+// goroutines.
+//
+// Note that the terminal width cannot be read on systems other than Linux
+// and there is no resize signal on systems other than Unix so the width
+// will only change there if SetWidth is called.
+type ResizingTWConf struct {
+	twc TWConf
+	fd  uintptr
+
+	width atomic.Int64
+
+	resizeMu sync.Mutex
+	onResize func(TWConf)
+	resizing bool // true while the onResize func is being called
+	resized  bool // true if the width changed while resizing
+
+	sigs     chan os.Signal
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewResizingTWConf returns a ResizingTWConf which will provide copies of
+// the given TWConf with the TargetLineLen set to the width of the terminal
+// open on the file descriptor (for instance os.Stdout.Fd()). The initial
+// width is found as for SetTargetLineLenFromTerminal with the TWConf's
+// TargetLineLen as the fallback.
+//
+// If onResize is not nil it is called with the new TWConf each time the
+// width changes. This can be used to re-render the last block of text
+// printed. It is called from a separate goroutine but calls are never
+// made concurrently.
+//
+// The Stop method should be called when the ResizingTWConf is no longer
+// needed.
+func NewResizingTWConf(
+	twc TWConf, fd uintptr, onResize func(TWConf),
+) *ResizingTWConf {
+	r := &ResizingTWConf{
+		twc:      twc,
+		fd:       fd,
+		onResize: onResize,
+		sigs:     make(chan os.Signal, 1),
+		done:     make(chan struct{}),
+	}
+	r.width.Store(int64(r.clamp(terminalWidth(fd, twc.TargetLineLen))))
+
+	if len(resizeSignals) > 0 {
+		signal.Notify(r.sigs, resizeSignals...)
+
+		go r.watch()
+	}
+
+	return r
+}
+
+// clamp returns the width adjusted so that it is not less than the
+// MinCharsToPrint
+func (r *ResizingTWConf) clamp(width int) int {
+	return max(width, r.twc.MinCharsToPrint)
+}
+
+// watch waits for resize signals and records the new terminal width until
+// the ResizingTWConf is stopped
+func (r *ResizingTWConf) watch() {
+	for {
+		select {
+		case <-r.sigs:
+			if w, ok := ttyWidth(r.fd); ok {
+				r.SetWidth(w)
+			}
+		case <-r.done:
+			return
+		}
+	}
+}
+
+// TWConf returns a copy of the TWConf with the TargetLineLen set to the
+// current width
+func (r *ResizingTWConf) TWConf() TWConf {
+	twc := r.twc
+	twc.TargetLineLen = r.Width()
+
+	return twc
+}
+
+// Width returns the current width
+func (r *ResizingTWConf) Width() int {
+	return int(r.width.Load())
+}
+
+// SetWidth sets the width, as if the terminal had been resized. It will not
+// be set below the MinCharsToPrint and values less than or equal to zero
+// are ignored. If the width changes then the onResize func (if any) is
+// called before SetWidth returns. If the onResize func is already being
+// called, from this or another goroutine, then it is called again with
+// the new width once that call returns, so it may itself call SetWidth.
+func (r *ResizingTWConf) SetWidth(width int) {
+	if width <= 0 {
+		return
+	}
+
+	width = r.clamp(width)
+
+	if int(r.width.Swap(int64(width))) == width || r.onResize == nil {
+		return
+	}
+
+	r.resizeMu.Lock()
+	if r.resizing {
+		r.resized = true
+		r.resizeMu.Unlock()
+
+		return
+	}
+
+	r.resizing = true
+	r.resizeMu.Unlock()
+
+	// the lock is not held while onResize is called so that it can call
+	// SetWidth or anything else needing the lock
+	for {
+		r.onResize(r.TWConf())
+
+		r.resizeMu.Lock()
+		again := r.resized
+		r.resized = false
+		r.resizing = again
+		r.resizeMu.Unlock()
+
+		if !again {
+			return
+		}
+	}
+}
+
+// Stop stops listening for resize signals. The width will no longer change
+// except through calls to SetWidth. It is safe to call Stop more than once.
+func (r *ResizingTWConf) Stop() {
+	r.stopOnce.Do(func() {
+		signal.Stop(r.sigs)
+		close(r.done)
+	})
+}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package twrap
+
+import "os"
+
+// resizeSignals is empty as there is no signal sent when the terminal is
+// resized on this platform
+var resizeSignals = []os.Signal{}
@@ -0,0 +1,138 @@
+package twrap_test
+
+import (
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+	"github.com/nickwells/twrap.mod/twrap"
+)
+
+func TestResizingTWConf(t *testing.T) {
+	t.Setenv(twrap.ColumnsEnvVar, "")
+
+	// a regular file is not a terminal so its width cannot be read
+	f, err := os.CreateTemp(t.TempDir(), "notATerminal")
+	if err != nil {
+		t.Fatal("couldn't create the temporary file:", err)
+	}
+
+	defer f.Close()
+
+	twc := twrap.NewTWConfOrPanic(
+		twrap.SetMinChars(10),
+		twrap.SetTargetLineLen(20))
+
+	var rendered []string
+
+	r := twrap.NewResizingTWConf(*twc, f.Fd(), func(twc twrap.TWConf) {
+		rendered = append(rendered,
+			twc.WrapString("123 567 901 345 789 123 567 901", 0))
+	})
+	defer r.Stop()
+
+	testCases := []struct {
+		testhelper.ID
+		width       int
+		expWidth    int
+		expRendered []string
+	}{
+		{
+			ID:       testhelper.MkID("initial width"),
+			expWidth: 20,
+		},
+		{
+			ID:       testhelper.MkID("wider"),
+			width:    30,
+			expWidth: 30,
+			expRendered: []string{
+				"123 567 901 345 789 123 567\n901\n",
+			},
+		},
+		{
+			ID:       testhelper.MkID("unchanged"),
+			width:    30,
+			expWidth: 30,
+		},
+		{
+			ID:       testhelper.MkID("ignored"),
+			width:    -1,
+			expWidth: 30,
+		},
+		{
+			ID:       testhelper.MkID("narrower than min chars"),
+			width:    5,
+			expWidth: 10,
+			expRendered: []string{
+				"123 567\n901 345\n789 123\n567 901\n",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		rendered = nil
+
+		r.SetWidth(tc.width)
+		testhelper.DiffInt(t, tc.IDStr(), "width", r.Width(), tc.expWidth)
+		testhelper.DiffInt(t, tc.IDStr(), "TargetLineLen",
+			r.TWConf().TargetLineLen, tc.expWidth)
+		testhelper.DiffStringSlice(t, tc.IDStr(), "re-rendered text",
+			rendered, tc.expRendered)
+	}
+
+	r.Stop()
+	r.Stop()
+}
+
+func TestResizingTWConfConcurrent(t *testing.T) {
+	twc := twrap.NewTWConfOrPanic(
+		twrap.SetMinChars(10),
+		twrap.SetTargetLineLen(20))
+
+	calls := 0
+	r := twrap.NewResizingTWConf(*twc, ^uintptr(0),
+		func(_ twrap.TWConf) { calls++ })
+	defer r.Stop()
+
+	var wg sync.WaitGroup
+
+	for i := range 10 {
+		wg.Go(func() {
+			for w := range 50 {
+				r.SetWidth(20 + (i+w)%10)
+				_ = r.TWConf().WrapString("some text to wrap", 2)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	if calls == 0 {
+		t.Error("the resize func was never called")
+	}
+}
+
+func TestResizingTWConfReentrant(t *testing.T) {
+	twc := twrap.NewTWConfOrPanic(
+		twrap.SetMinChars(10),
+		twrap.SetTargetLineLen(20))
+
+	var widths []int
+
+	var r *twrap.ResizingTWConf
+
+	r = twrap.NewResizingTWConf(*twc, ^uintptr(0), func(twc twrap.TWConf) {
+		widths = append(widths, twc.TargetLineLen)
+		if twc.TargetLineLen < 40 {
+			r.SetWidth(twc.TargetLineLen + 10)
+		}
+	})
+	defer r.Stop()
+
+	r.SetWidth(25)
+
+	testhelper.DiffInt(t, "after SetWidth", "width", r.Width(), 45)
+	testhelper.DiffSlice(t, "after SetWidth", "widths passed to onResize",
+		widths, []int{25, 35, 45})
+}
@@ -0,0 +1,246 @@
+package twrap
+
+import "strings"
+
+// sprint calls the function with a copy of the TWConf whose writer has been
+// replaced with a strings.Builder and returns the resulting text.
+func (twc TWConf) sprint(f func(TWConf)) string {
+	var b strings.Builder
+
+	twc.W = &b
+	f(twc)
+
+	return b.String()
+}
+
+// toLines splits the text into lines, each without its trailing newline
+func toLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// WrapString returns the text wrapped and indented as it would be printed
+// by Wrap
+func (twc TWConf) WrapString(text string, indent int) string {
+	return twc.sprint(func(twc TWConf) { twc.Wrap(text, indent) })
+}
+
+// WrapLines returns the lines of text as they would be printed by Wrap. The
+// lines do not have a trailing newline.
+func (twc TWConf) WrapLines(text string, indent int) []string {
+	return toLines(twc.WrapString(text, indent))
+}
+
+// Wrap2IndentString returns the text wrapped and indented as it would be
+// printed by Wrap2Indent
+func (twc TWConf) Wrap2IndentString(
+	text string,
+	firstLineIndent, otherLineIndent int,
+) string {
+	return twc.sprint(func(twc TWConf) {
+		twc.Wrap2Indent(text, firstLineIndent, otherLineIndent)
+	})
+}
+
+// Wrap2IndentLines returns the lines of text as they would be printed by
+// Wrap2Indent. The lines do not have a trailing newline.
+func (twc TWConf) Wrap2IndentLines(
+	text string,
+	firstLineIndent, otherLineIndent int,
+) []string {
+	return toLines(
+		twc.Wrap2IndentString(text, firstLineIndent, otherLineIndent))
+}
+
+// Wrap3IndentString returns the text wrapped and indented as it would be
+// printed by Wrap3Indent
+func (twc TWConf) Wrap3IndentString(
+	text string,
+	line1Indent, paraLine1Indent, line2Indent int,
+) string {
+	return twc.sprint(func(twc TWConf) {
+		twc.Wrap3Indent(text, line1Indent, paraLine1Indent, line2Indent)
+	})
+}
+
+// Wrap3IndentLines returns the lines of text as they would be printed by
+// Wrap3Indent. The lines do not have a trailing newline.
+func (twc TWConf) Wrap3IndentLines(
+	text string,
+	line1Indent, paraLine1Indent, line2Indent int,
+) []string {
+	return toLines(
+		twc.Wrap3IndentString(text,
+			line1Indent, paraLine1Indent, line2Indent))
+}
+
+// WrapPrefixedString returns the text wrapped and indented as it would be
+// printed by WrapPrefixed
+func (twc TWConf) WrapPrefixedString(prefix, text string, indent int) string {
+	return twc.sprint(func(twc TWConf) {
+		twc.WrapPrefixed(prefix, text, indent)
+	})
+}
+
+// WrapPrefixedLines returns the lines of text as they would be printed by
+// WrapPrefixed. The lines do not have a trailing newline.
+func (twc TWConf) WrapPrefixedLines(prefix, text string, indent int) []string {
+	return toLines(twc.WrapPrefixedString(prefix, text, indent))
+}
+
+// ListString returns the list as it would be printed by List
+func (twc TWConf) ListString(list []string, indent int) string {
+	return twc.sprint(func(twc TWConf) { twc.List(list, indent) })
+}
+
+// ListLines returns the lines of the list as they would be printed by
+// List. The lines do not have a trailing newline.
+func (twc TWConf) ListLines(list []string, indent int) []string {
+	return toLines(twc.ListString(list, indent))
+}
+
+// IdxListString returns the list as it would be printed by IdxList
+func (twc TWConf) IdxListString(list []string, indent int) string {
+	return twc.sprint(func(twc TWConf) { twc.IdxList(list, indent) })
+}
+
+// IdxListLines returns the lines of the list as they would be printed by
+// IdxList. The lines do not have a trailing newline.
+func (twc TWConf) IdxListLines(list []string, indent int) []string {
+	return toLines(twc.IdxListString(list, indent))
+}
+
+// NoRptListString returns the list as it would be printed by NoRptList
+func (twc TWConf) NoRptListString(list []string, indent int) string {
+	return twc.sprint(func(twc TWConf) { twc.NoRptList(list, indent) })
+}
+
+// NoRptListLines returns the lines of the list as they would be printed by
+// NoRptList. The lines do not have a trailing newline.
+func (twc TWConf) NoRptListLines(list []string, indent int) []string {
+	return toLines(twc.NoRptListString(list, indent))
+}
+
+// IdxNoRptListString returns the list as it would be printed by
+// IdxNoRptList
+func (twc TWConf) IdxNoRptListString(list []string, indent int) string {
+	return twc.sprint(func(twc TWConf) { twc.IdxNoRptList(list, indent) })
+}
+
+// IdxNoRptListLines returns the lines of the list as they would be printed
+// by IdxNoRptList. The lines do not have a trailing newline.
+func (twc TWConf) IdxNoRptListLines(list []string, indent int) []string {
+	return toLines(twc.IdxNoRptListString(list, indent))
+}
+
+// NoRptPathListString returns the list as it would be printed by
+// NoRptPathList
+func (twc TWConf) NoRptPathListString(list []string, indent int) string {
+	return twc.sprint(func(twc TWConf) { twc.NoRptPathList(list, indent) })
+}
+
+// NoRptPathListLines returns the lines of the list as they would be printed
+// by NoRptPathList. The lines do not have a trailing newline.
+func (twc TWConf) NoRptPathListLines(list []string, indent int) []string {
+	return toLines(twc.NoRptPathListString(list, indent))
+}
+
+// IdxNoRptPathListString returns the list as it would be printed by
+// IdxNoRptPathList
+func (twc TWConf) IdxNoRptPathListString(list []string, indent int) string {
+	return twc.sprint(func(twc TWConf) { twc.IdxNoRptPathList(list, indent) })
+}
+
+// IdxNoRptPathListLines returns the lines of the list as they would be
+// printed by IdxNoRptPathList. The lines do not have a trailing newline.
+func (twc TWConf) IdxNoRptPathListLines(list []string, indent int) []string {
+	return toLines(twc.IdxNoRptPathListString(list, indent))
+}
+
+// WrapCommandString returns the command line wrapped as it would be printed
+// by WrapCommand
+func (twc TWConf) WrapCommandString(cmd string, indent int) string {
+	return twc.sprint(func(twc TWConf) { twc.WrapCommand(cmd, indent) })
+}
+
+// WrapCommandLines returns the lines of the command line as they would be
+// printed by WrapCommand. The lines do not have a trailing newline.
+func (twc TWConf) WrapCommandLines(cmd string, indent int) []string {
+	return toLines(twc.WrapCommandString(cmd, indent))
+}
+
+// ColumnsString returns the text as it would be printed by Columns
+func (twc TWConf) ColumnsString(text string, cols, indent int) string {
+	return twc.sprint(func(twc TWConf) { twc.Columns(text, cols, indent) })
+}
+
+// ColumnsLines returns the lines of text as they would be printed by
+// Columns. The lines do not have a trailing newline.
+func (twc TWConf) ColumnsLines(text string, cols, indent int) []string {
+	return toLines(twc.ColumnsString(text, cols, indent))
+}
+
+// ListColumnsString returns the list as it would be printed by ListColumns
+func (twc TWConf) ListColumnsString(list []string, indent int) string {
+	return twc.sprint(func(twc TWConf) { twc.ListColumns(list, indent) })
+}
+
+// ListColumnsLines returns the lines of the list as they would be printed
+// by ListColumns. The lines do not have a trailing newline.
+func (twc TWConf) ListColumnsLines(list []string, indent int) []string {
+	return toLines(twc.ListColumnsString(list, indent))
+}
+
+// String returns the table as it would be printed by Print
+func (t *Table) String(indent int) string {
+	return t.twc.sprint(func(twc TWConf) {
+		tt := *t
+		tt.twc = twc
+		tt.Print(indent)
+	})
+}
+
+// Lines returns the lines of the table as they would be printed by Print.
+// The lines do not have a trailing newline.
+func (t *Table) Lines(indent int) []string {
+	return toLines(t.String(indent))
+}
+
+// DefListString returns the definitions as they would be printed by
+// DefList
+func (twc TWConf) DefListString(defs []DefListItem, indent int) string {
+	return twc.sprint(func(twc TWConf) { twc.DefList(defs, indent) })
+}
+
+// DefListLines returns the lines of the definitions as they would be
+// printed by DefList. The lines do not have a trailing newline.
+func (twc TWConf) DefListLines(defs []DefListItem, indent int) []string {
+	return toLines(twc.DefListString(defs, indent))
+}
+
+// TreeListString returns the tree of items as it would be printed by
+// TreeList
+func (twc TWConf) TreeListString(items []TreeItem, indent int) string {
+	return twc.sprint(func(twc TWConf) { twc.TreeList(items, indent) })
+}
+
+// TreeListLines returns the lines of the tree of items as they would be
+// printed by TreeList. The lines do not have a trailing newline.
+func (twc TWConf) TreeListLines(items []TreeItem, indent int) []string {
+	return toLines(twc.TreeListString(items, indent))
+}
+
+// IdxTreeListString returns the tree of items as it would be printed by
+// IdxTreeList
+func (twc TWConf) IdxTreeListString(items []TreeItem, indent int) string {
+	return twc.sprint(func(twc TWConf) { twc.IdxTreeList(items, indent) })
+}
+
+// IdxTreeListLines returns the lines of the tree of items as they would be
+// printed by IdxTreeList. The lines do not have a trailing newline.
+func (twc TWConf) IdxTreeListLines(items []TreeItem, indent int) []string {
+	return toLines(twc.IdxTreeListString(items, indent))
+}
@@ -0,0 +1,113 @@
+package twrap_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+	"github.com/nickwells/twrap.mod/twrap"
+)
+
+func TestWrapString(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		indent   int
+		text     string
+		expText  string
+		expLines []string
+	}{
+		{
+			ID:   testhelper.MkID("no indent"),
+			text: "123 567 901 345 789 123 567 901",
+			expText: `123 567 901 345 789
+123 567 901
+`,
+			expLines: []string{
+				"123 567 901 345 789",
+				"123 567 901",
+			},
+		},
+		{
+			ID:     testhelper.MkID("indent = 5, blank line"),
+			indent: 5,
+			text:   "123 567\n\n901",
+			expText: `     123 567
+
+     901
+`,
+			expLines: []string{
+				"     123 567",
+				"",
+				"     901",
+			},
+		},
+		{
+			ID: testhelper.MkID("empty string"),
+		},
+	}
+
+	twc := twrap.NewTWConfOrPanic(
+		twrap.SetMinChars(10),
+		twrap.SetTargetLineLen(20))
+
+	for _, tc := range testCases {
+		testhelper.DiffString(t, tc.IDStr(), "wrapped text",
+			twc.WrapString(tc.text, tc.indent), tc.expText)
+		testhelper.DiffStringSlice(t, tc.IDStr(), "wrapped lines",
+			twc.WrapLines(tc.text, tc.indent), tc.expLines)
+	}
+}
+
+func TestListString(t *testing.T) {
+	list := []string{
+		"part1/part2/entry1",
+		"part1/part2/entry2: Very long text that is expected to wrap." +
+			" Blah, blah, blah, blah, blah, blah, blah.",
+		"part1/part3/entry3",
+	}
+
+	for _, l := range []struct {
+		f    func(twrap.TWConf, []string, int) (int, error)
+		fStr func(twrap.TWConf, []string, int) string
+		name string
+	}{
+		{
+			f:    twrap.TWConf.List,
+			fStr: twrap.TWConf.ListString,
+			name: "List",
+		},
+		{
+			f:    twrap.TWConf.IdxList,
+			fStr: twrap.TWConf.IdxListString,
+			name: "IdxList",
+		},
+		{
+			f:    twrap.TWConf.NoRptList,
+			fStr: twrap.TWConf.NoRptListString,
+			name: "NoRptList",
+		},
+		{
+			f:    twrap.TWConf.NoRptPathList,
+			fStr: twrap.TWConf.NoRptPathListString,
+			name: "NoRptPathList",
+		},
+		{
+			f:    twrap.TWConf.IdxNoRptList,
+			fStr: twrap.TWConf.IdxNoRptListString,
+			name: "IdxNoRptList",
+		},
+		{
+			f:    twrap.TWConf.IdxNoRptPathList,
+			fStr: twrap.TWConf.IdxNoRptPathListString,
+			name: "IdxNoRptPathList",
+		},
+	} {
+		b := bytes.Buffer{}
+		twc := twrap.NewTWConfOrPanic(
+			twrap.SetWriter(&b),
+			twrap.SetTargetLineLen(40))
+		l.f(*twc, list, 4)
+		testhelper.DiffString(t, l.name, "list text",
+			l.fStr(*twc, list, 4), b.String())
+	}
+}
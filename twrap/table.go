@@ -0,0 +1,251 @@
+package twrap
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// TableCol describes a column of a Table. The width of the column is the
+// width of its widest cell but never less than the MinWidth or, if it is
+// greater than zero, more than the MaxWidth.
+//
+// If Wrap is true the cells are wrapped to fit the column, using the same
+// rules as Wrap but with any word too long for the column split, and the
+// column will be narrowed, though never below its MinWidth, if that is
+// needed to fit the table within the TargetLineLen. Otherwise any cell
+// too wide for the column is truncated and ends with the Ellipsis.
+type TableCol struct {
+	Header   string
+	MinWidth int
+	MaxWidth int
+	Align    Alignment
+	Wrap     bool
+}
+
+// Table holds rows of cells to be printed in aligned columns. The columns
+// are separated by the TWConf's Gutter. Use the TWConf's NewTable method to
+// create one.
+type Table struct {
+	twc  TWConf
+	cols []TableCol
+	rows [][]string
+}
+
+// NewTable returns a Table with the given columns which will be printed
+// using the TWConf. It returns an error if any column has a negative width
+// or a MaxWidth less than its MinWidth or an unknown alignment.
+func (twc TWConf) NewTable(cols ...TableCol) (*Table, error) {
+	if len(cols) == 0 {
+		return nil, errors.New("a table must have at least one column")
+	}
+
+	for i, c := range cols {
+		if c.MinWidth < 0 || c.MaxWidth < 0 {
+			return nil, fmt.Errorf("column %d: the widths must be >= 0", i)
+		}
+
+		if c.MaxWidth > 0 && c.MaxWidth < c.MinWidth {
+			return nil, fmt.Errorf(
+				"column %d: the MaxWidth (%d) is less than the MinWidth (%d)",
+				i, c.MaxWidth, c.MinWidth)
+		}
+
+		if !c.Align.IsValid() {
+			return nil, fmt.Errorf("column %d: bad alignment: %d", i, c.Align)
+		}
+	}
+
+	return &Table{twc: twc, cols: cols}, nil
+}
+
+// AddRow adds a row of cells to the table. A row may have fewer cells than
+// the table has columns, in which case the remaining cells are empty, but
+// not more. A cell may hold several lines separated by newlines.
+func (t *Table) AddRow(cells ...string) error {
+	if len(cells) > len(t.cols) {
+		return fmt.Errorf("too many cells: %d, the table has %d columns",
+			len(cells), len(t.cols))
+	}
+
+	t.rows = append(t.rows, cells)
+
+	return nil
+}
+
+// Print prints the table indented by the given amount. If any column has a
+// Header a row of headers is printed first. Each row is as tall as its
+// tallest cell and the lines of each cell are aligned within its column.
+//
+// It returns the number of bytes written and any error encountered.
+func (t *Table) Print(indent int) (int, error) {
+	return t.twc.captureLimited(indent, func(twc TWConf) {
+		rows := t.rows
+		if t.hasHeaders() {
+			headers := make([]string, 0, len(t.cols))
+			for _, c := range t.cols {
+				headers = append(headers, c.Header)
+			}
+
+			rows = append([][]string{headers}, rows...)
+		}
+
+		widths := t.colWidths(rows, indent)
+
+		for _, row := range rows {
+			twc.printPackedColumns(t.rowColumns(row, widths), widths, indent)
+		}
+	})
+}
+
+// hasHeaders returns true if any column has a Header
+func (t *Table) hasHeaders() bool {
+	for _, c := range t.cols {
+		if c.Header != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// colWidths returns the width of each column. The columns are first made
+// wide enough for their widest cells within the column limits and then, if
+// the table is too wide, the widest of the wrapped columns is narrowed
+// until it fits or none can be narrowed any further.
+func (t *Table) colWidths(rows [][]string, indent int) []int {
+	widths := make([]int, len(t.cols))
+
+	for _, row := range rows {
+		for i, cell := range row {
+			for line := range strings.SplitSeq(cell, "\n") {
+				widths[i] = max(widths[i], strWidth(line))
+			}
+		}
+	}
+
+	total := t.twc.Gutter * (len(t.cols) - 1)
+
+	for i, c := range t.cols {
+		widths[i] = max(widths[i], c.MinWidth, 1)
+		if c.MaxWidth > 0 {
+			widths[i] = min(widths[i], c.MaxWidth)
+		}
+
+		total += widths[i]
+	}
+
+	for excess := total - t.twc.columnsWidth(indent); excess > 0; excess-- {
+		widest := -1
+
+		for i, c := range t.cols {
+			if c.Wrap && widths[i] > max(c.MinWidth, 1) &&
+				(widest < 0 || widths[i] > widths[widest]) {
+				widest = i
+			}
+		}
+
+		if widest < 0 {
+			break
+		}
+
+		widths[widest]--
+	}
+
+	return widths
+}
+
+// rowColumns returns the lines of each cell in the row, wrapped or
+// truncated to fit the column width and aligned within it
+func (t *Table) rowColumns(row []string, widths []int) [][]string {
+	columns := make([][]string, len(t.cols))
+	height := 1
+
+	for i, c := range t.cols {
+		var cell string
+		if i < len(row) {
+			cell = row[i]
+		}
+
+		var lines []string
+		if c.Wrap {
+			lines = t.wrapCell(cell, c, widths[i])
+		} else {
+			lines = truncCell(cell, widths[i], t.twc.Ellipsis)
+		}
+
+		for _, line := range lines {
+			columns[i] = append(columns[i], alignCell(line, widths[i], c.Align))
+		}
+
+		height = max(height, len(columns[i]))
+	}
+
+	// every column must have a line for every row so that the columns to
+	// its right stay aligned
+	for i := range columns {
+		for len(columns[i]) < height {
+			columns[i] = append(columns[i], "")
+		}
+	}
+
+	return columns
+}
+
+// wrapCell returns the lines of the cell wrapped to fit the width. Words
+// too long for the column are split, unless the TWConf's Overflow policy
+// already deals with them, so that the table keeps within its width.
+func (t *Table) wrapCell(cell string, c TableCol, width int) []string {
+	cellTWC := t.twc
+	cellTWC.TargetLineLen = width
+	cellTWC.MinCharsToPrint = min(t.twc.MinCharsToPrint, width)
+	cellTWC.LineLeader = ""
+	cellTWC.MaxLines = 0
+	cellTWC.Align = AlignLeft
+
+	if cellTWC.Overflow == OverflowAllow {
+		cellTWC.Overflow = OverflowSplit
+	}
+
+	if c.Align == AlignJustify {
+		cellTWC.Align = AlignJustify
+	}
+
+	return cellTWC.WrapLines(cell, 0)
+}
+
+// truncCell returns the lines of the cell, each truncated to fit the width
+// and ending with the ellipsis if it is too wide
+func truncCell(cell string, width int, ellipsis string) []string {
+	var lines []string
+
+	for line := range strings.SplitSeq(cell, "\n") {
+		if strWidth(line) > width {
+			head, tail := splitAtWidth(line, width-strWidth(ellipsis))
+			line = head + ellipsis + escSeqs(tail)
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// alignCell returns the line padded with leading spaces so that it is
+// aligned within the width. Trailing padding is added when the columns are
+// printed.
+func alignCell(line string, width int, a Alignment) string {
+	gap := width - strWidth(line)
+	if gap <= 0 {
+		return line
+	}
+
+	switch a {
+	case AlignRight:
+		return strings.Repeat(" ", gap) + line
+	case AlignCentre:
+		return strings.Repeat(" ", gap/2) + line
+	}
+
+	return line
+}
@@ -0,0 +1,155 @@
+package twrap_test
+
+import (
+	"testing"
+
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+	"github.com/nickwells/twrap.mod/twrap"
+)
+
+func TestTable(t *testing.T) {
+	const desc = "the quick brown fox jumps over the lazy dog"
+
+	testCases := []struct {
+		testhelper.ID
+		cols     []twrap.TableCol
+		rows     [][]string
+		indent   int
+		expLines []string
+	}{
+		{
+			ID: testhelper.MkID("no wrapping needed"),
+			cols: []twrap.TableCol{
+				{Header: "Name"},
+				{Header: "Size", Align: twrap.AlignRight},
+			},
+			rows: [][]string{
+				{"alpha", "1"},
+				{"b", "1024"},
+			},
+			expLines: []string{
+				"Name   Size",
+				"alpha     1",
+				"b      1024",
+			},
+		},
+		{
+			ID: testhelper.MkID("wrapped column, indented"),
+			cols: []twrap.TableCol{
+				{MinWidth: 6},
+				{Wrap: true},
+			},
+			rows: [][]string{
+				{"-v", desc},
+				{"--help", "show help"},
+			},
+			indent: 2,
+			expLines: []string{
+				"  -v      the quick brown fox",
+				"          jumps over the lazy dog",
+				"  --help  show help",
+			},
+		},
+		{
+			ID: testhelper.MkID("unbreakable word in a wrapped column"),
+			cols: []twrap.TableCol{
+				{},
+				{Wrap: true},
+			},
+			rows: [][]string{
+				{"home", "see https://example.com/a/very/long/path/to/a/page"},
+			},
+			expLines: []string{
+				"home  see",
+				"      https://example.com/a/very/l",
+				"      ong/path/to/a/page",
+			},
+		},
+		{
+			ID: testhelper.MkID("max width, truncated and centred"),
+			cols: []twrap.TableCol{
+				{MaxWidth: 5},
+				{MaxWidth: 10, Wrap: true, Align: twrap.AlignCentre},
+				{},
+			},
+			rows: [][]string{
+				{"abcdefgh", desc, "x"},
+				{"ab"},
+			},
+			expLines: []string{
+				"abcd…  the quick   x",
+				"       brown fox",
+				"       jumps over",
+				"        the lazy",
+				"          dog",
+				"ab",
+			},
+		},
+	}
+
+	twc := twrap.NewTWConfOrPanic(
+		twrap.SetMinChars(5),
+		twrap.SetTargetLineLen(34))
+
+	for _, tc := range testCases {
+		tbl, err := twc.NewTable(tc.cols...)
+		if err != nil {
+			t.Fatal(tc.IDStr(), ": unexpected error: ", err)
+		}
+
+		for _, r := range tc.rows {
+			if err := tbl.AddRow(r...); err != nil {
+				t.Fatal(tc.IDStr(), ": unexpected error: ", err)
+			}
+		}
+
+		testhelper.DiffStringSlice(t, tc.IDStr(), "table lines",
+			tbl.Lines(tc.indent), tc.expLines)
+	}
+}
+
+func TestTableErrs(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		testhelper.ExpErr
+		cols []twrap.TableCol
+	}{
+		{
+			ID:     testhelper.MkID("no columns"),
+			ExpErr: testhelper.MkExpErr("at least one column"),
+		},
+		{
+			ID:     testhelper.MkID("negative width"),
+			ExpErr: testhelper.MkExpErr("column 1: the widths must be >= 0"),
+			cols:   []twrap.TableCol{{}, {MinWidth: -1}},
+		},
+		{
+			ID: testhelper.MkID("max less than min"),
+			ExpErr: testhelper.MkExpErr(
+				"column 0: the MaxWidth (2) is less than the MinWidth (3)"),
+			cols: []twrap.TableCol{{MinWidth: 3, MaxWidth: 2}},
+		},
+		{
+			ID:     testhelper.MkID("bad alignment"),
+			ExpErr: testhelper.MkExpErr("column 0: bad alignment: 99"),
+			cols:   []twrap.TableCol{{Align: 99}},
+		},
+	}
+
+	twc := twrap.NewTWConfOrPanic()
+
+	for _, tc := range testCases {
+		_, err := twc.NewTable(tc.cols...)
+		testhelper.CheckExpErr(t, err, tc)
+	}
+
+	tbl, err := twc.NewTable(twrap.TableCol{})
+	if err != nil {
+		t.Fatal("unexpected error: ", err)
+	}
+
+	err = tbl.AddRow("a", "b")
+	if err == nil {
+		t.Error("expected an error adding too many cells")
+	}
+}
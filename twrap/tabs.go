@@ -0,0 +1,54 @@
+package twrap
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// expandTabs returns the text with each tab replaced by enough spaces to
+// reach the next tab stop. The col is the column at which the text starts
+// (counting from zero). The text is returned unchanged if the TabWidth is
+// zero.
+func (twc TWConf) expandTabs(text string, col int) string {
+	if twc.TabWidth <= 0 || !strings.ContainsRune(text, '\t') {
+		return text
+	}
+
+	var b strings.Builder
+
+	for i := 0; i < len(text); {
+		if n := escSeqLen(text[i:]); n > 0 {
+			b.WriteString(text[i : i+n])
+			i += n
+
+			continue
+		}
+
+		r, n := utf8.DecodeRuneInString(text[i:])
+		i += n
+
+		if r == '\t' {
+			stop := (col/twc.TabWidth + 1) * twc.TabWidth
+			b.WriteString(strings.Repeat(" ", stop-col))
+			col = stop
+
+			continue
+		}
+
+		b.WriteRune(r)
+		col += runeWidth(r)
+	}
+
+	return b.String()
+}
+
+// maxSpacesWidth returns the greatest width that the spaces could take
+// once any tabs have been expanded, whatever column they start at
+func (twc TWConf) maxSpacesWidth(spaces string) int {
+	width := strWidth(spaces)
+	if twc.TabWidth > 1 {
+		width += strings.Count(spaces, "\t") * (twc.TabWidth - 1)
+	}
+
+	return width
+}
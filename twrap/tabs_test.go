@@ -0,0 +1,107 @@
+package twrap_test
+
+import (
+	"testing"
+
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+	"github.com/nickwells/twrap.mod/twrap"
+)
+
+func TestTabWrap(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		opts     []twrap.TWConfOptFunc
+		indent   int
+		text     string
+		expLines []string
+	}{
+		{
+			ID:   testhelper.MkID("no tab width"),
+			text: "a\tb",
+			expLines: []string{
+				"a\tb",
+			},
+		},
+		{
+			ID:   testhelper.MkID("no tab width, wrapped"),
+			text: "a\tb\tc\td\te\tf\tg\th\ti\tj\tk\tl",
+			expLines: []string{
+				"a\tb\tc\td\te\tf\tg\th",
+				"i\tj\tk\tl",
+			},
+		},
+		{
+			ID:   testhelper.MkID("tab width 4"),
+			opts: []twrap.TWConfOptFunc{twrap.SetTabWidth(4)},
+			text: "a\tb\tcde\tf",
+			expLines: []string{
+				"a   b   cde f",
+			},
+		},
+		{
+			ID:     testhelper.MkID("tab width 4, indent 2"),
+			opts:   []twrap.TWConfOptFunc{twrap.SetTabWidth(4)},
+			indent: 2,
+			text:   "\ta\tb",
+			expLines: []string{
+				"    a   b",
+			},
+		},
+		{
+			ID:   testhelper.MkID("tab width 8, wrapped"),
+			opts: []twrap.TWConfOptFunc{twrap.SetTabWidth(8)},
+			text: "abc\tdef\tghi\tjkl",
+			expLines: []string{
+				"abc     def",
+				"ghi     jkl",
+			},
+		},
+		{
+			ID: testhelper.MkID("tab width 8, optimal"),
+			opts: []twrap.TWConfOptFunc{
+				twrap.SetTabWidth(8),
+				twrap.SetBreakStrategy(twrap.BreakOptimal),
+			},
+			text: "abc\tdef\tghi\tjkl",
+			expLines: []string{
+				"abc     def",
+				"ghi     jkl",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		opts := append([]twrap.TWConfOptFunc{
+			twrap.SetMinChars(5),
+			twrap.SetTargetLineLen(15),
+		}, tc.opts...)
+		twc := twrap.NewTWConfOrPanic(opts...)
+
+		testhelper.DiffStringSlice(t, tc.IDStr(), "wrapped lines",
+			twc.WrapLines(tc.text, tc.indent), tc.expLines)
+	}
+}
+
+func TestTabList(t *testing.T) {
+	twc := twrap.NewTWConfOrPanic(twrap.SetTabWidth(4))
+
+	testhelper.DiffStringSlice(t, "NoRptList", "list lines",
+		twc.NoRptListLines([]string{"a\tb", "a\tc", "ab\tc"}, 1),
+		[]string{
+			" - a    b",
+			" -      c",
+			" -  b   c",
+		})
+	testhelper.DiffStringSlice(t, "IdxNoRptList", "list lines",
+		twc.IdxNoRptListLines([]string{"a\tb", "a\tc"}, 0),
+		[]string{
+			"- 1: a  b",
+			"- 2:    c",
+		})
+	testhelper.DiffStringSlice(t, "NoRptPathList", "list lines",
+		twc.NoRptPathListLines([]string{"a\tb/c", "a\tb/d"}, 0),
+		[]string{
+			"- a b/c",
+			"-     d",
+		})
+}
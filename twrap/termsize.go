@@ -0,0 +1,26 @@
+package twrap
+
+import (
+	"os"
+	"strconv"
+)
+
+// ColumnsEnvVar is the name of the environment variable checked for the
+// terminal width if it cannot be read from the terminal itself
+const ColumnsEnvVar = "COLUMNS"
+
+// terminalWidth returns the width of the terminal open on the file
+// descriptor. If this cannot be found then the value of the COLUMNS
+// environment variable is used if it is a positive number and otherwise the
+// fallback value.
+func terminalWidth(fd uintptr, fallback int) int {
+	if w, ok := ttyWidth(fd); ok {
+		return w
+	}
+
+	if w, err := strconv.Atoi(os.Getenv(ColumnsEnvVar)); err == nil && w > 0 {
+		return w
+	}
+
+	return fallback
+}
@@ -0,0 +1,28 @@
+//go:build linux
+
+package twrap
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// winsize mirrors the kernel's struct winsize as used by TIOCGWINSZ
+type winsize struct {
+	rows, cols, xPixels, yPixels uint16
+}
+
+// ttyWidth returns the width in columns of the terminal open on the file
+// descriptor. It returns false if the descriptor is not a terminal or the
+// width cannot be found.
+func ttyWidth(fd uintptr) (int, bool) {
+	var ws winsize
+
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL,
+		fd, uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws)))
+	if errno != 0 || ws.cols == 0 {
+		return 0, false
+	}
+
+	return int(ws.cols), true
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package twrap
+
+// ttyWidth always returns false as the terminal width cannot be read on
+// this platform
+func ttyWidth(_ uintptr) (int, bool) {
+	return 0, false
+}
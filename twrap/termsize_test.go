@@ -0,0 +1,78 @@
+package twrap_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+	"github.com/nickwells/twrap.mod/twrap"
+)
+
+func TestSetTargetLineLenFromTerminal(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		columns  string
+		fallback int
+		minChars int
+		expLen   int
+	}{
+		{
+			ID:       testhelper.MkID("COLUMNS set"),
+			columns:  "100",
+			fallback: 60,
+			expLen:   100,
+		},
+		{
+			ID:       testhelper.MkID("COLUMNS not set"),
+			fallback: 60,
+			expLen:   60,
+		},
+		{
+			ID:       testhelper.MkID("COLUMNS bad"),
+			columns:  "wide",
+			fallback: 60,
+			expLen:   60,
+		},
+		{
+			ID:       testhelper.MkID("COLUMNS negative"),
+			columns:  "-5",
+			fallback: 60,
+			expLen:   60,
+		},
+		{
+			ID:     testhelper.MkID("no fallback"),
+			expLen: twrap.DfltTargetLineLen,
+		},
+		{
+			ID:       testhelper.MkID("narrower than min chars"),
+			columns:  "10",
+			minChars: 20,
+			expLen:   20,
+		},
+	}
+
+	// a regular file is not a terminal so its width cannot be read
+	f, err := os.CreateTemp(t.TempDir(), "notATerminal")
+	if err != nil {
+		t.Fatal("couldn't create the temporary file:", err)
+	}
+
+	defer f.Close()
+
+	for _, tc := range testCases {
+		t.Setenv(twrap.ColumnsEnvVar, tc.columns)
+
+		twc, err := twrap.NewTWConf(
+			twrap.SetMinChars(tc.minChars),
+			twrap.SetTargetLineLenFromTerminal(f.Fd(), tc.fallback))
+		if err != nil {
+			t.Log(tc.IDStr())
+			t.Errorf("\t: unexpected error: %v", err)
+
+			continue
+		}
+
+		testhelper.DiffInt(t, tc.IDStr(), "TargetLineLen",
+			twc.TargetLineLen, tc.expLen)
+	}
+}
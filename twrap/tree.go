@@ -0,0 +1,72 @@
+package twrap
+
+import (
+	"strconv"
+	"strings"
+)
+
+// DfltTreeBullets returns the default bullets for the levels of a TreeList.
+// A new slice is returned each time so that it can be changed safely.
+func DfltTreeBullets() []string {
+	return []string{"- ", "* ", "+ "}
+}
+
+// TreeItem is an item in a nested list. The Children are printed below the
+// item and indented so that they line up with the start of its text.
+type TreeItem struct {
+	Text     string
+	Children []TreeItem
+}
+
+// TreeList will print the tree of items. Each item is prefixed with the
+// bullet for its level (see SetTreeBullets) and wrapped with a hanging
+// indent so that the following lines line up with the start of its text.
+// The top level items are printed at the indent.
+//
+// It returns the number of bytes written and any error encountered.
+func (twc TWConf) TreeList(items []TreeItem, indent int) (int, error) {
+	return twc.captureLimited(indent, func(twc TWConf) {
+		twc.treeList(items, indent, 0)
+	})
+}
+
+// treeList prints the items, which are at the given level in the tree,
+// and their children
+func (twc TWConf) treeList(items []TreeItem, indent, level int) {
+	bullet := ""
+	if len(twc.TreeBullets) > 0 {
+		bullet = twc.TreeBullets[level%len(twc.TreeBullets)]
+	}
+
+	for _, item := range items {
+		twc.wrapPrefixed(bullet, item.Text, indent)
+		twc.treeList(item.Children, indent+strWidth(bullet), level+1)
+	}
+}
+
+// IdxTreeList will print the tree of items as for TreeList but with each
+// item prefixed by its hierarchical index number. The top level items are
+// numbered "1.", "2." and so on, their children "1.1.", "1.2." and so on,
+// and the same for each lower level of the tree. The index numbers of the
+// items sharing a parent are right aligned so that their text lines up.
+//
+// It returns the number of bytes written and any error encountered.
+func (twc TWConf) IdxTreeList(items []TreeItem, indent int) (int, error) {
+	return twc.captureLimited(indent, func(twc TWConf) {
+		twc.idxTreeList(items, indent, "")
+	})
+}
+
+// idxTreeList prints the items, with index numbers starting with the
+// parent's index, and their children
+func (twc TWConf) idxTreeList(items []TreeItem, indent int, parentIdx string) {
+	width := len(parentIdx + strconv.Itoa(len(items)) + ". ")
+
+	for i, item := range items {
+		idx := parentIdx + strconv.Itoa(i+1) + "."
+		prefix := strings.Repeat(" ", width-len(idx)-1) + idx + " "
+
+		twc.wrapPrefixed(prefix, item.Text, indent)
+		twc.idxTreeList(item.Children, indent+width, idx)
+	}
+}
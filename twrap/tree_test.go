@@ -0,0 +1,137 @@
+package twrap_test
+
+import (
+	"testing"
+
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+	"github.com/nickwells/twrap.mod/twrap"
+)
+
+var testTree = []twrap.TreeItem{
+	{
+		Text: "fruit which grows on trees or bushes",
+		Children: []twrap.TreeItem{
+			{Text: "apples"},
+			{
+				Text: "berries",
+				Children: []twrap.TreeItem{
+					{Text: "strawberries"},
+					{Text: "blackcurrants, redcurrants and whitecurrants"},
+				},
+			},
+		},
+	},
+	{Text: "vegetables"},
+}
+
+func TestTreeList(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		opts     []twrap.TWConfOptFunc
+		items    []twrap.TreeItem
+		indent   int
+		expLines []string
+	}{
+		{
+			ID: testhelper.MkID("empty"),
+		},
+		{
+			ID:     testhelper.MkID("default bullets"),
+			items:  testTree,
+			indent: 1,
+			expLines: []string{
+				" - fruit which grows on trees or",
+				"   bushes",
+				"   * apples",
+				"   * berries",
+				"     + strawberries",
+				"     + blackcurrants, redcurrants",
+				"       and whitecurrants",
+				" - vegetables",
+			},
+		},
+		{
+			ID:    testhelper.MkID("bullets reused"),
+			opts:  []twrap.TWConfOptFunc{twrap.SetTreeBullets("o ", "-- ")},
+			items: testTree,
+			expLines: []string{
+				"o fruit which grows on trees or",
+				"  bushes",
+				"  -- apples",
+				"  -- berries",
+				"     o strawberries",
+				"     o blackcurrants, redcurrants",
+				"       and whitecurrants",
+				"o vegetables",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		opts := append([]twrap.TWConfOptFunc{
+			twrap.SetMinChars(5),
+			twrap.SetTargetLineLen(34),
+		}, tc.opts...)
+		twc := twrap.NewTWConfOrPanic(opts...)
+
+		testhelper.DiffStringSlice(t, tc.IDStr(), "tree lines",
+			twc.TreeListLines(tc.items, tc.indent), tc.expLines)
+	}
+}
+
+func TestIdxTreeList(t *testing.T) {
+	many := make([]twrap.TreeItem, 10)
+	for i := range many {
+		many[i].Text = "item"
+	}
+
+	many[9].Children = []twrap.TreeItem{{Text: "child"}}
+
+	testCases := []struct {
+		testhelper.ID
+		items    []twrap.TreeItem
+		expLines []string
+	}{
+		{
+			ID:    testhelper.MkID("nested"),
+			items: testTree,
+			expLines: []string{
+				"1. fruit which grows on trees or",
+				"   bushes",
+				"   1.1. apples",
+				"   1.2. berries",
+				"        1.2.1. strawberries",
+				"        1.2.2. blackcurrants,",
+				"               redcurrants and",
+				"               whitecurrants",
+				"2. vegetables",
+			},
+		},
+		{
+			ID:    testhelper.MkID("aligned indexes"),
+			items: many,
+			expLines: []string{
+				" 1. item",
+				" 2. item",
+				" 3. item",
+				" 4. item",
+				" 5. item",
+				" 6. item",
+				" 7. item",
+				" 8. item",
+				" 9. item",
+				"10. item",
+				"    10.1. child",
+			},
+		},
+	}
+
+	twc := twrap.NewTWConfOrPanic(
+		twrap.SetMinChars(5),
+		twrap.SetTargetLineLen(34))
+
+	for _, tc := range testCases {
+		testhelper.DiffStringSlice(t, tc.IDStr(), "tree lines",
+			twc.IdxTreeListLines(tc.items, 0), tc.expLines)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"regexp"
 )
 
 // These establish the default values for a TWConf.
@@ -12,6 +13,7 @@ const (
 	DfltMinCharsToPrint = 30
 	DfltTargetLineLen   = 80
 	DfltListPrefix      = "- "
+	DfltVerbatimFence   = "```"
 )
 
 // TWConf holds the configuration for a text wrapper
@@ -20,6 +22,47 @@ type TWConf struct {
 	MinCharsToPrint int
 	TargetLineLen   int
 	ListPrefix      string
+	Align           Alignment
+	BreakStrategy   BreakStrategy
+
+	Overflow           OverflowPolicy
+	OverflowBreakChars string
+	SplitMarker        string
+	Ellipsis           string
+
+	Hyphenator Hyphenator
+
+	TabWidth int
+
+	VerbatimIndent int
+	VerbatimFence  string
+
+	LineLeader  string
+	BreakMarker string
+	ContMarker  string
+
+	ParaBreak      *regexp.Regexp
+	BlankLineParas bool
+	ListMarkers    []ListMarkerFunc
+
+	MaxLines    int
+	TruncMarker string
+
+	Gutter          int
+	DefTermMaxWidth int
+
+	TreeBullets []string
+
+	IdxStep      int
+	IdxAlign     IdxAlignment
+	IdxNumbering IdxNumbering
+
+	// these can only be set through SetIdxStart and SetIdxSeparators so
+	// that a TWConf not made by NewTWConf still prints the default indexes
+	idxStartOffset int    // the offset of the first index from DfltIdxStart
+	idxSepsSet     bool   // true if idxOpen and idxClose are to be used
+	idxOpen        string // printed before each index
+	idxClose       string // printed after each index
 }
 
 // TWConfOptFunc is the signature of the function that is passed to the
@@ -82,6 +125,389 @@ func SetListPrefix(pfx string) TWConfOptFunc {
 	}
 }
 
+// SetAlignment returns a TWConfOptFunc suitable for passing to NewTWConf
+// which will set the Align value. The default is AlignLeft.
+func SetAlignment(a Alignment) TWConfOptFunc {
+	return func(twc *TWConf) error {
+		if !a.IsValid() {
+			return fmt.Errorf("bad alignment: %d", a)
+		}
+
+		twc.Align = a
+
+		return nil
+	}
+}
+
+// SetBreakStrategy returns a TWConfOptFunc suitable for passing to
+// NewTWConf which will set the BreakStrategy. The default is BreakGreedy.
+func SetBreakStrategy(bs BreakStrategy) TWConfOptFunc {
+	return func(twc *TWConf) error {
+		if !bs.IsValid() {
+			return fmt.Errorf("bad break strategy: %d", bs)
+		}
+
+		twc.BreakStrategy = bs
+
+		return nil
+	}
+}
+
+// SetOverflow returns a TWConfOptFunc suitable for passing to NewTWConf
+// which will set the Overflow policy. The default is OverflowAllow.
+func SetOverflow(op OverflowPolicy) TWConfOptFunc {
+	return func(twc *TWConf) error {
+		if !op.IsValid() {
+			return fmt.Errorf("bad overflow policy: %d", op)
+		}
+
+		twc.Overflow = op
+
+		return nil
+	}
+}
+
+// SetOverflowBreakChars returns a TWConfOptFunc suitable for passing to
+// NewTWConf which will set the OverflowBreakChars. These are the characters
+// after which an over-long word is split when the Overflow policy is
+// OverflowBreakChars. The characters must not be empty.
+func SetOverflowBreakChars(chars string) TWConfOptFunc {
+	return func(twc *TWConf) error {
+		if chars == "" {
+			return errors.New("the overflow break chars must not be empty")
+		}
+
+		twc.OverflowBreakChars = chars
+
+		return nil
+	}
+}
+
+// SetSplitMarker returns a TWConfOptFunc suitable for passing to NewTWConf
+// which will set the SplitMarker. This is printed at the end of each part
+// of a split word but the last. The default is to print nothing.
+func SetSplitMarker(marker string) TWConfOptFunc {
+	return func(twc *TWConf) error {
+		twc.SplitMarker = marker
+		return nil
+	}
+}
+
+// SetEllipsis returns a TWConfOptFunc suitable for passing to NewTWConf
+// which will set the Ellipsis. This is printed at the end of a truncated
+// word.
+func SetEllipsis(ellipsis string) TWConfOptFunc {
+	return func(twc *TWConf) error {
+		twc.Ellipsis = ellipsis
+		return nil
+	}
+}
+
+// SetHyphenator returns a TWConfOptFunc suitable for passing to NewTWConf
+// which will set the Hyphenator. If this is not nil it is used to find the
+// points at which a word which doesn't fit at the end of a line may be
+// broken with a hyphen. Soft hyphens in the text are always honoured,
+// whether or not there is a Hyphenator.
+func SetHyphenator(h Hyphenator) TWConfOptFunc {
+	return func(twc *TWConf) error {
+		twc.Hyphenator = h
+		return nil
+	}
+}
+
+// SetTabWidth returns a TWConfOptFunc suitable for passing to NewTWConf
+// which will set the TabWidth. If this is greater than zero then tabs are
+// replaced by enough spaces to reach the next tab stop, the tab stops being
+// every TabWidth columns counting from the start of the line (including any
+// indent). Otherwise, the default, tabs are printed as is and each is
+// taken to be one column wide, as the width it takes depends on the
+// terminal. The value must be greater or equal to zero.
+func SetTabWidth(n int) TWConfOptFunc {
+	return func(twc *TWConf) error {
+		if n < 0 {
+			return errors.New("the tab width must be >= 0")
+		}
+
+		twc.TabWidth = n
+
+		return nil
+	}
+}
+
+// SetVerbatimIndent returns a TWConfOptFunc suitable for passing to
+// NewTWConf which will set the VerbatimIndent. If this is greater than zero
+// then any paragraph starting with at least this many spaces (or with a
+// tab) is printed as is, without wrapping, with just the indent added. This
+// allows code samples and diagrams to be embedded in wrapped text. The
+// default is zero, no paragraphs are printed verbatim. The value must be
+// greater or equal to zero.
+func SetVerbatimIndent(n int) TWConfOptFunc {
+	return func(twc *TWConf) error {
+		if n < 0 {
+			return errors.New("the verbatim indent must be >= 0")
+		}
+
+		twc.VerbatimIndent = n
+
+		return nil
+	}
+}
+
+// SetVerbatimFence returns a TWConfOptFunc suitable for passing to
+// NewTWConf which will set the VerbatimFence. If this is not empty then
+// any paragraphs between a paragraph starting with the fence and one
+// consisting of just the fence are printed as is, without wrapping, with
+// just the indent added. The fences themselves are not printed. The
+// default is empty, so there are no fenced blocks; DfltVerbatimFence
+// gives the Markdown code fence.
+func SetVerbatimFence(fence string) TWConfOptFunc {
+	return func(twc *TWConf) error {
+		twc.VerbatimFence = fence
+		return nil
+	}
+}
+
+// SetLineLeader returns a TWConfOptFunc suitable for passing to NewTWConf
+// which will set the LineLeader. This is printed at the start of every
+// line, before any indent, and its width is taken from the TargetLineLen.
+// It can be used to print the text as comments ("// " or "# ") or as a
+// quotation ("> "). Blank lines are printed as the leader with any
+// trailing spaces removed. The default is no leader.
+func SetLineLeader(leader string) TWConfOptFunc {
+	return func(twc *TWConf) error {
+		twc.LineLeader = leader
+		return nil
+	}
+}
+
+// SetBreakMarker returns a TWConfOptFunc suitable for passing to NewTWConf
+// which will set the BreakMarker. This is printed at the end of each line
+// which has been broken by the wrapping, for instance " \\" or "↩", so that
+// it is clear that the line continues. Room is left for the marker at the
+// end of every wrapped line. The default is no marker.
+func SetBreakMarker(marker string) TWConfOptFunc {
+	return func(twc *TWConf) error {
+		twc.BreakMarker = marker
+		return nil
+	}
+}
+
+// SetContMarker returns a TWConfOptFunc suitable for passing to NewTWConf
+// which will set the ContMarker. This is printed after the indent at the
+// start of each continuation line, the lines of a paragraph after the
+// first, for instance "↪ ". Its width is taken from the space available for
+// the text. The default is no marker.
+func SetContMarker(marker string) TWConfOptFunc {
+	return func(twc *TWConf) error {
+		twc.ContMarker = marker
+		return nil
+	}
+}
+
+// SetParaBreak returns a TWConfOptFunc suitable for passing to NewTWConf
+// which will set the ParaBreak. This is the regular expression used to
+// split the text into paragraphs. Any line breaks within a paragraph are
+// treated as spaces. The default is to break the text at each newline or
+// form feed. The regular expression must not be nil.
+func SetParaBreak(re *regexp.Regexp) TWConfOptFunc {
+	return func(twc *TWConf) error {
+		if re == nil {
+			return errors.New("the paragraph break must not be nil")
+		}
+
+		twc.ParaBreak = re
+
+		return nil
+	}
+}
+
+// SetBlankLineParas returns a TWConfOptFunc suitable for passing to
+// NewTWConf which will set BlankLineParas. If this is true then only blank
+// lines or form feeds separate paragraphs; single newlines are treated as
+// spaces so that text which has already been broken into lines (such as a
+// Go doc comment or Markdown) can be rewrapped. A single blank line is
+// printed between paragraphs and empty paragraphs are not printed. If the
+// ParaBreak is also set then that is used to split the paragraphs instead.
+func SetBlankLineParas(b bool) TWConfOptFunc {
+	return func(twc *TWConf) error {
+		twc.BlankLineParas = b
+		return nil
+	}
+}
+
+// SetListMarkers returns a TWConfOptFunc suitable for passing to NewTWConf
+// which will set the ListMarkers. These are used to recognise paragraphs
+// which are items in a list; the lines of these paragraphs after the first
+// are indented to line up with the text following the marker. The markers
+// are tried in turn and the first to match is used. The default is to
+// recognise paragraphs starting with "- ", "* " or "+ ". If no markers are
+// given then no paragraphs are taken to be list items.
+func SetListMarkers(markers ...ListMarkerFunc) TWConfOptFunc {
+	return func(twc *TWConf) error {
+		twc.ListMarkers = append([]ListMarkerFunc{}, markers...)
+		return nil
+	}
+}
+
+// SetMaxLines returns a TWConfOptFunc suitable for passing to NewTWConf
+// which will set MaxLines. If this is greater than zero then no more than
+// this many lines are printed by each call of a TWConf or Table method or
+// by a Writer; if there would be more then the last line is replaced by
+// the TruncMarker. The default is zero, there is no limit. The value must
+// be greater or equal to zero.
+func SetMaxLines(n int) TWConfOptFunc {
+	return func(twc *TWConf) error {
+		if n < 0 {
+			return errors.New("the maximum number of lines must be >= 0")
+		}
+
+		twc.MaxLines = n
+
+		return nil
+	}
+}
+
+// SetTruncMarker returns a TWConfOptFunc suitable for passing to NewTWConf
+// which will set the TruncMarker. This is printed in place of the lines
+// beyond the MaxLines limit. Any "%d" in the marker is replaced by the
+// number of lines not shown. It is indented as the first line of the text
+// but it will be shortened if necessary to fit within the TargetLineLen.
+func SetTruncMarker(marker string) TWConfOptFunc {
+	return func(twc *TWConf) error {
+		twc.TruncMarker = marker
+		return nil
+	}
+}
+
+// SetGutter returns a TWConfOptFunc suitable for passing to NewTWConf
+// which will set the Gutter. This is the number of spaces between the
+// columns printed by the Columns and ListColumns methods, the columns of a
+// Table and the terms and descriptions of a DefList. The value must be
+// greater or equal to zero.
+func SetGutter(n int) TWConfOptFunc {
+	return func(twc *TWConf) error {
+		if n < 0 {
+			return errors.New("the gutter must be >= 0")
+		}
+
+		twc.Gutter = n
+
+		return nil
+	}
+}
+
+// SetDefTermMaxWidth returns a TWConfOptFunc suitable for passing to
+// NewTWConf which will set the DefTermMaxWidth. This is the widest that the
+// column of terms printed by DefList can be. The value must be greater
+// than zero.
+func SetDefTermMaxWidth(n int) TWConfOptFunc {
+	return func(twc *TWConf) error {
+		if n <= 0 {
+			return errors.New("the maximum definition term width must be > 0")
+		}
+
+		twc.DefTermMaxWidth = n
+
+		return nil
+	}
+}
+
+// SetTreeBullets returns a TWConfOptFunc suitable for passing to NewTWConf
+// which will set the TreeBullets. These are the prefixes of the items
+// printed by TreeList, the first for the top level items, the second for
+// their children and so on. If there are more levels than bullets the
+// bullets are used again from the first. At least one bullet must be given.
+func SetTreeBullets(bullets ...string) TWConfOptFunc {
+	return func(twc *TWConf) error {
+		if len(bullets) == 0 {
+			return errors.New("at least one tree bullet must be given")
+		}
+
+		twc.TreeBullets = bullets
+
+		return nil
+	}
+}
+
+// SetIdxStart returns a TWConfOptFunc suitable for passing to NewTWConf
+// which will set the index of the first item printed by the IdxList,
+// IdxNoRptList and IdxNoRptPathList methods. The value must be greater or
+// equal to zero. The default is 1.
+func SetIdxStart(n int) TWConfOptFunc {
+	return func(twc *TWConf) error {
+		if n < 0 {
+			return errors.New("the starting index must be >= 0")
+		}
+
+		twc.idxStartOffset = n - DfltIdxStart
+
+		return nil
+	}
+}
+
+// SetIdxStep returns a TWConfOptFunc suitable for passing to NewTWConf
+// which will set the IdxStep. This is the amount by which the index of
+// each item printed by the IdxList methods is greater than the one before.
+// The value must be greater than zero. The default is 1.
+func SetIdxStep(n int) TWConfOptFunc {
+	return func(twc *TWConf) error {
+		if n <= 0 {
+			return errors.New("the index step must be > 0")
+		}
+
+		twc.IdxStep = n
+
+		return nil
+	}
+}
+
+// SetIdxSeparators returns a TWConfOptFunc suitable for passing to
+// NewTWConf which will set the strings printed before and after the index
+// of each item printed by the IdxList methods so, for instance, passing
+// "[" and "]" gives indexes like "[1]" and passing "" and ")" gives "1)".
+// The defaults are "" and ":".
+func SetIdxSeparators(open, closing string) TWConfOptFunc {
+	return func(twc *TWConf) error {
+		twc.idxSepsSet = true
+		twc.idxOpen = open
+		twc.idxClose = closing
+
+		return nil
+	}
+}
+
+// SetIdxAlign returns a TWConfOptFunc suitable for passing to NewTWConf
+// which will set the IdxAlign value. This gives the alignment of the
+// indexes printed by the IdxList methods when they are not all the same
+// width. The default is IdxAlignRight.
+func SetIdxAlign(a IdxAlignment) TWConfOptFunc {
+	return func(twc *TWConf) error {
+		if !a.IsValid() {
+			return fmt.Errorf("bad index alignment: %d", a)
+		}
+
+		twc.IdxAlign = a
+
+		return nil
+	}
+}
+
+// SetIdxNumbering returns a TWConfOptFunc suitable for passing to NewTWConf
+// which will set the IdxNumbering value. This gives the numbering system
+// used for the indexes printed by the IdxList methods. The default is
+// IdxDecimal.
+func SetIdxNumbering(n IdxNumbering) TWConfOptFunc {
+	return func(twc *TWConf) error {
+		if !n.IsValid() {
+			return fmt.Errorf("bad index numbering: %d", n)
+		}
+
+		twc.IdxNumbering = n
+
+		return nil
+	}
+}
+
 // TWConfOptSetTargetLineLen returns an option func that will set the target
 // line length on a TWConf
 //
@@ -105,6 +531,31 @@ func SetTargetLineLen(n int) TWConfOptFunc {
 	}
 }
 
+// SetTargetLineLenFromTerminal returns a TWConfOptFunc suitable for passing
+// to NewTWConf which will set the TargetLineLen to the width of the
+// terminal open on the file descriptor (for instance os.Stdout.Fd()). If
+// the width cannot be read from the terminal (if the descriptor is not a
+// terminal, say, or on a system other than Linux) then the value of the
+// COLUMNS environment variable is used and if that is not set (or is not a
+// positive number) the fallback value is used. If the fallback is not
+// greater than zero then DfltTargetLineLen is used instead.
+//
+// The TargetLineLen will not be set below the MinCharsToPrint so that a
+// very narrow terminal will not cause NewTWConf to fail. This means that
+// any SetMinChars option should be given before this one.
+func SetTargetLineLenFromTerminal(fd uintptr, fallback int) TWConfOptFunc {
+	return func(twc *TWConf) error {
+		if fallback <= 0 {
+			fallback = DfltTargetLineLen
+		}
+
+		twc.TargetLineLen = max(terminalWidth(fd, fallback),
+			twc.MinCharsToPrint)
+
+		return nil
+	}
+}
+
 // NewTWConf constructs a TWConf with the default values. To override the
 // default values pass the appropriate option functions. If any of the
 // option funcs returns an error the error is returned and a nil value
@@ -114,6 +565,16 @@ func NewTWConf(opts ...TWConfOptFunc) (*TWConf, error) {
 		MinCharsToPrint: DfltMinCharsToPrint,
 		TargetLineLen:   DfltTargetLineLen,
 		ListPrefix:      DfltListPrefix,
+
+		OverflowBreakChars: DfltOverflowBreakChars,
+		Ellipsis:           DfltEllipsis,
+
+		TruncMarker: DfltTruncMarker,
+
+		Gutter:          DfltGutter,
+		DefTermMaxWidth: DfltDefTermMaxWidth,
+
+		TreeBullets: DfltTreeBullets(),
 	}
 
 	for _, o := range opts {
@@ -0,0 +1,117 @@
+package twrap
+
+import "strings"
+
+// paraMode records how the current paragraph is to be printed
+type paraMode int
+
+const (
+	paraUndecided paraMode = iota // not enough of the paragraph seen yet
+	paraWrapped                   // the paragraph is wrapped
+	paraVerbatim                  // the paragraph is printed as is
+	paraFence                     // the paragraph is or is within a fence
+)
+
+// isVerbatimIndented returns true if the paragraph starts with enough
+// indentation to be printed verbatim
+func (twc TWConf) isVerbatimIndented(para string) bool {
+	if twc.VerbatimIndent <= 0 {
+		return false
+	}
+
+	return strings.HasPrefix(para, "\t") ||
+		strings.HasPrefix(para, strings.Repeat(" ", twc.VerbatimIndent))
+}
+
+// isFence returns true if the paragraph starts with the VerbatimFence
+func (twc TWConf) isFence(para string) bool {
+	return twc.VerbatimFence != "" &&
+		strings.HasPrefix(para, twc.VerbatimFence)
+}
+
+// mightBeVerbatim returns true if the start of the paragraph could be
+// the start of either a fence or a verbatim indent
+func (twc TWConf) mightBeVerbatim(head string) bool {
+	return strings.HasPrefix(twc.VerbatimFence, head) ||
+		(twc.VerbatimIndent > 0 && strings.Trim(head, " ") == "")
+}
+
+// decideMode decides how the paragraph held so far is to be printed. It
+// returns false if more of the paragraph is needed before this can be
+// decided; if the paragraph has ended it will always decide.
+func (w *wrapper) decideMode(paraEnded bool) bool {
+	head := string(w.held)
+
+	switch {
+	case w.inFence || w.twc.isFence(head):
+		w.mode = paraFence
+	case w.twc.isVerbatimIndented(head):
+		w.mode = paraVerbatim
+	case !paraEnded && w.twc.mightBeVerbatim(head):
+		return false
+	default:
+		w.mode = paraWrapped
+	}
+
+	return true
+}
+
+// endFence handles the end of a paragraph which either starts with a fence
+// or is within a fenced block. The fences themselves are not printed; the
+// lines between them are printed verbatim.
+func (w *wrapper) endFence() {
+	var lines []string
+
+	for line := range strings.SplitSeq(string(w.held), "\n") {
+		switch {
+		case !w.inFence && w.twc.isFence(line):
+			w.inFence = true
+		case w.inFence &&
+			strings.TrimSpace(line) == w.twc.VerbatimFence:
+			w.inFence = false
+		default:
+			lines = append(lines, line)
+		}
+	}
+
+	if len(lines) > 0 {
+		w.held = append(w.held[:0], strings.Join(lines, "\n")...)
+		w.printVerbatim()
+	}
+}
+
+// printVerbatim prints the paragraph held without wrapping it. Each line
+// is prefixed with the indent for the first line of the paragraph and any
+// tabs are expanded.
+func (w *wrapper) printVerbatim() {
+	if w.twc.BlankLineParas && strings.TrimSpace(string(w.held)) == "" {
+		return
+	}
+
+	w.startOutput()
+
+	var b strings.Builder
+
+	for line := range strings.SplitSeq(string(w.held), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if line == "" {
+			b.WriteString(w.twc.blankLine())
+			continue
+		}
+
+		b.WriteString(w.line1Prefix)
+		b.WriteString(w.style.open())
+
+		text := w.twc.expandTabs(line, strWidth(w.line1Prefix))
+		b.WriteString(text)
+		w.style.update(text)
+
+		if w.style.isSet() {
+			b.WriteString(sgrReset)
+		}
+
+		b.WriteString("\n")
+	}
+
+	w.twc.Print(b.String())
+}
@@ -0,0 +1,110 @@
+package twrap_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+	"github.com/nickwells/twrap.mod/twrap"
+)
+
+func TestVerbatim(t *testing.T) {
+	const text = "Some text which will need to be wrapped.\n" +
+		"    $ cmd --flag value   # a comment which is long\n" +
+		"```\n" +
+		"+-----+    +-----+\n" +
+		"| box | -> | box |\n" +
+		"\n" +
+		"+-----+    +-----+\n" +
+		"```\n" +
+		"More text which will also need to be wrapped."
+
+	testCases := []struct {
+		testhelper.ID
+		opts     []twrap.TWConfOptFunc
+		expLines []string
+	}{
+		{
+			ID: testhelper.MkID("no verbatim rules"),
+			expLines: []string{
+				"  Some text which will",
+				"  need to be wrapped.",
+				"      $ cmd --flag",
+				"  value   # a comment",
+				"  which is long",
+				"  ```",
+				"  +-----+    +-----+",
+				"  | box | -> | box |",
+				"",
+				"  +-----+    +-----+",
+				"  ```",
+				"  More text which will",
+				"  also need to be",
+				"  wrapped.",
+			},
+		},
+		{
+			ID: testhelper.MkID("verbatim indent"),
+			opts: []twrap.TWConfOptFunc{
+				twrap.SetVerbatimIndent(4),
+			},
+			expLines: []string{
+				"  Some text which will",
+				"  need to be wrapped.",
+				"      $ cmd --flag value   # a comment which is long",
+				"  ```",
+				"  +-----+    +-----+",
+				"  | box | -> | box |",
+				"",
+				"  +-----+    +-----+",
+				"  ```",
+				"  More text which will",
+				"  also need to be",
+				"  wrapped.",
+			},
+		},
+		{
+			ID: testhelper.MkID("verbatim indent and fence"),
+			opts: []twrap.TWConfOptFunc{
+				twrap.SetVerbatimIndent(4),
+				twrap.SetVerbatimFence(twrap.DfltVerbatimFence),
+			},
+			expLines: []string{
+				"  Some text which will",
+				"  need to be wrapped.",
+				"      $ cmd --flag value   # a comment which is long",
+				"  +-----+    +-----+",
+				"  | box | -> | box |",
+				"",
+				"  +-----+    +-----+",
+				"  More text which will",
+				"  also need to be",
+				"  wrapped.",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		opts := append([]twrap.TWConfOptFunc{
+			twrap.SetMinChars(10),
+			twrap.SetTargetLineLen(22),
+		}, tc.opts...)
+		twc := twrap.NewTWConfOrPanic(opts...)
+
+		testhelper.DiffStringSlice(t, tc.IDStr(), "wrapped lines",
+			twc.WrapLines(text, 2), tc.expLines)
+
+		var b strings.Builder
+
+		twc.W = &b
+		w := twrap.NewWriter(*twc, 2)
+
+		for _, c := range []byte(text) {
+			_, _ = w.Write([]byte{c})
+		}
+
+		_ = w.Close()
+		testhelper.DiffString(t, tc.IDStr(), "Writer output",
+			b.String(), strings.Join(tc.expLines, "\n")+"\n")
+	}
+}
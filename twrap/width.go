@@ -0,0 +1,189 @@
+package twrap
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// eaWide holds the ranges of runes having an East Asian Width property of
+// Wide (W) or Fullwidth (F). These occupy two cells on a terminal.
+var eaWide = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x1100, Hi: 0x115f, Stride: 1},
+		{Lo: 0x231a, Hi: 0x231b, Stride: 1},
+		{Lo: 0x2329, Hi: 0x232a, Stride: 1},
+		{Lo: 0x23e9, Hi: 0x23ec, Stride: 1},
+		{Lo: 0x23f0, Hi: 0x23f0, Stride: 1},
+		{Lo: 0x23f3, Hi: 0x23f3, Stride: 1},
+		{Lo: 0x25fd, Hi: 0x25fe, Stride: 1},
+		{Lo: 0x2614, Hi: 0x2615, Stride: 1},
+		{Lo: 0x2648, Hi: 0x2653, Stride: 1},
+		{Lo: 0x267f, Hi: 0x267f, Stride: 1},
+		{Lo: 0x2693, Hi: 0x2693, Stride: 1},
+		{Lo: 0x26a1, Hi: 0x26a1, Stride: 1},
+		{Lo: 0x26aa, Hi: 0x26ab, Stride: 1},
+		{Lo: 0x26bd, Hi: 0x26be, Stride: 1},
+		{Lo: 0x26c4, Hi: 0x26c5, Stride: 1},
+		{Lo: 0x26ce, Hi: 0x26ce, Stride: 1},
+		{Lo: 0x26d4, Hi: 0x26d4, Stride: 1},
+		{Lo: 0x26ea, Hi: 0x26ea, Stride: 1},
+		{Lo: 0x26f2, Hi: 0x26f3, Stride: 1},
+		{Lo: 0x26f5, Hi: 0x26f5, Stride: 1},
+		{Lo: 0x26fa, Hi: 0x26fa, Stride: 1},
+		{Lo: 0x26fd, Hi: 0x26fd, Stride: 1},
+		{Lo: 0x2705, Hi: 0x2705, Stride: 1},
+		{Lo: 0x270a, Hi: 0x270b, Stride: 1},
+		{Lo: 0x2728, Hi: 0x2728, Stride: 1},
+		{Lo: 0x274c, Hi: 0x274c, Stride: 1},
+		{Lo: 0x274e, Hi: 0x274e, Stride: 1},
+		{Lo: 0x2753, Hi: 0x2755, Stride: 1},
+		{Lo: 0x2757, Hi: 0x2757, Stride: 1},
+		{Lo: 0x2795, Hi: 0x2797, Stride: 1},
+		{Lo: 0x27b0, Hi: 0x27b0, Stride: 1},
+		{Lo: 0x27bf, Hi: 0x27bf, Stride: 1},
+		{Lo: 0x2b1b, Hi: 0x2b1c, Stride: 1},
+		{Lo: 0x2b50, Hi: 0x2b50, Stride: 1},
+		{Lo: 0x2b55, Hi: 0x2b55, Stride: 1},
+		{Lo: 0x2e80, Hi: 0x2e99, Stride: 1},
+		{Lo: 0x2e9b, Hi: 0x2ef3, Stride: 1},
+		{Lo: 0x2f00, Hi: 0x2fd5, Stride: 1},
+		{Lo: 0x2ff0, Hi: 0x2fff, Stride: 1},
+		{Lo: 0x3000, Hi: 0x303e, Stride: 1},
+		{Lo: 0x3041, Hi: 0x3096, Stride: 1},
+		{Lo: 0x3099, Hi: 0x30ff, Stride: 1},
+		{Lo: 0x3105, Hi: 0x312f, Stride: 1},
+		{Lo: 0x3131, Hi: 0x318e, Stride: 1},
+		{Lo: 0x3190, Hi: 0x31e5, Stride: 1},
+		{Lo: 0x31ef, Hi: 0x321e, Stride: 1},
+		{Lo: 0x3220, Hi: 0x3247, Stride: 1},
+		{Lo: 0x3250, Hi: 0xa48c, Stride: 1},
+		{Lo: 0xa490, Hi: 0xa4c6, Stride: 1},
+		{Lo: 0xa960, Hi: 0xa97c, Stride: 1},
+		{Lo: 0xac00, Hi: 0xd7a3, Stride: 1},
+		{Lo: 0xf900, Hi: 0xfaff, Stride: 1},
+		{Lo: 0xfe10, Hi: 0xfe19, Stride: 1},
+		{Lo: 0xfe30, Hi: 0xfe52, Stride: 1},
+		{Lo: 0xfe54, Hi: 0xfe66, Stride: 1},
+		{Lo: 0xfe68, Hi: 0xfe6b, Stride: 1},
+		{Lo: 0xff01, Hi: 0xff60, Stride: 1},
+		{Lo: 0xffe0, Hi: 0xffe6, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x16fe0, Hi: 0x16fe4, Stride: 1},
+		{Lo: 0x16ff0, Hi: 0x16ff1, Stride: 1},
+		{Lo: 0x17000, Hi: 0x187f7, Stride: 1},
+		{Lo: 0x18800, Hi: 0x18cd5, Stride: 1},
+		{Lo: 0x18d00, Hi: 0x18d08, Stride: 1},
+		{Lo: 0x1aff0, Hi: 0x1aff3, Stride: 1},
+		{Lo: 0x1aff5, Hi: 0x1affb, Stride: 1},
+		{Lo: 0x1affd, Hi: 0x1affe, Stride: 1},
+		{Lo: 0x1b000, Hi: 0x1b122, Stride: 1},
+		{Lo: 0x1b132, Hi: 0x1b132, Stride: 1},
+		{Lo: 0x1b150, Hi: 0x1b152, Stride: 1},
+		{Lo: 0x1b155, Hi: 0x1b155, Stride: 1},
+		{Lo: 0x1b164, Hi: 0x1b167, Stride: 1},
+		{Lo: 0x1b170, Hi: 0x1b2fb, Stride: 1},
+		{Lo: 0x1f004, Hi: 0x1f004, Stride: 1},
+		{Lo: 0x1f0cf, Hi: 0x1f0cf, Stride: 1},
+		{Lo: 0x1f18e, Hi: 0x1f18e, Stride: 1},
+		{Lo: 0x1f191, Hi: 0x1f19a, Stride: 1},
+		{Lo: 0x1f200, Hi: 0x1f202, Stride: 1},
+		{Lo: 0x1f210, Hi: 0x1f23b, Stride: 1},
+		{Lo: 0x1f240, Hi: 0x1f248, Stride: 1},
+		{Lo: 0x1f250, Hi: 0x1f251, Stride: 1},
+		{Lo: 0x1f260, Hi: 0x1f265, Stride: 1},
+		{Lo: 0x1f300, Hi: 0x1f320, Stride: 1},
+		{Lo: 0x1f32d, Hi: 0x1f335, Stride: 1},
+		{Lo: 0x1f337, Hi: 0x1f37c, Stride: 1},
+		{Lo: 0x1f37e, Hi: 0x1f393, Stride: 1},
+		{Lo: 0x1f3a0, Hi: 0x1f3ca, Stride: 1},
+		{Lo: 0x1f3cf, Hi: 0x1f3d3, Stride: 1},
+		{Lo: 0x1f3e0, Hi: 0x1f3f0, Stride: 1},
+		{Lo: 0x1f3f4, Hi: 0x1f3f4, Stride: 1},
+		{Lo: 0x1f3f8, Hi: 0x1f43e, Stride: 1},
+		{Lo: 0x1f440, Hi: 0x1f440, Stride: 1},
+		{Lo: 0x1f442, Hi: 0x1f4fc, Stride: 1},
+		{Lo: 0x1f4ff, Hi: 0x1f53d, Stride: 1},
+		{Lo: 0x1f54b, Hi: 0x1f54e, Stride: 1},
+		{Lo: 0x1f550, Hi: 0x1f567, Stride: 1},
+		{Lo: 0x1f57a, Hi: 0x1f57a, Stride: 1},
+		{Lo: 0x1f595, Hi: 0x1f596, Stride: 1},
+		{Lo: 0x1f5a4, Hi: 0x1f5a4, Stride: 1},
+		{Lo: 0x1f5fb, Hi: 0x1f64f, Stride: 1},
+		{Lo: 0x1f680, Hi: 0x1f6c5, Stride: 1},
+		{Lo: 0x1f6cc, Hi: 0x1f6cc, Stride: 1},
+		{Lo: 0x1f6d0, Hi: 0x1f6d2, Stride: 1},
+		{Lo: 0x1f6d5, Hi: 0x1f6d7, Stride: 1},
+		{Lo: 0x1f6dc, Hi: 0x1f6df, Stride: 1},
+		{Lo: 0x1f6eb, Hi: 0x1f6ec, Stride: 1},
+		{Lo: 0x1f6f4, Hi: 0x1f6fc, Stride: 1},
+		{Lo: 0x1f7e0, Hi: 0x1f7eb, Stride: 1},
+		{Lo: 0x1f7f0, Hi: 0x1f7f0, Stride: 1},
+		{Lo: 0x1f90c, Hi: 0x1f93a, Stride: 1},
+		{Lo: 0x1f93c, Hi: 0x1f945, Stride: 1},
+		{Lo: 0x1f947, Hi: 0x1f9ff, Stride: 1},
+		{Lo: 0x1fa70, Hi: 0x1fa7c, Stride: 1},
+		{Lo: 0x1fa80, Hi: 0x1fa89, Stride: 1},
+		{Lo: 0x1fa8f, Hi: 0x1fac6, Stride: 1},
+		{Lo: 0x1face, Hi: 0x1fadc, Stride: 1},
+		{Lo: 0x1fadf, Hi: 0x1fae9, Stride: 1},
+		{Lo: 0x1faf0, Hi: 0x1faf8, Stride: 1},
+		{Lo: 0x20000, Hi: 0x2fffd, Stride: 1},
+		{Lo: 0x30000, Hi: 0x3fffd, Stride: 1},
+	},
+}
+
+// zeroWidth holds the ranges of runes which take up no space on a
+// terminal. These are the Hangul medial vowels and final consonants which
+// combine with the preceding leading consonant to form a single wide
+// syllable.
+var zeroWidth = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x1160, Hi: 0x11ff, Stride: 1},
+		{Lo: 0xd7b0, Hi: 0xd7ff, Stride: 1},
+	},
+}
+
+// runeWidth returns the number of terminal cells that the rune will
+// occupy. Combining marks, format characters (such as the zero-width joiner
+// and the soft hyphen) and control characters other than tab take no
+// space, East Asian wide and fullwidth characters take two cells and
+// everything else takes one. A tab is counted as one cell as its width
+// depends on where it is printed; they are expanded before they are
+// measured if the TWConf has a TabWidth.
+func runeWidth(r rune) int {
+	switch {
+	case r == '\t':
+		return 1
+	case r < 0x20 || (r >= 0x7f && r < 0xa0) || r == softHyphen:
+		return 0
+	case r < 0x300:
+		return 1
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf, zeroWidth):
+		return 0
+	case unicode.Is(eaWide, r):
+		return 2
+	}
+
+	return 1
+}
+
+// strWidth returns the number of terminal cells that the string will
+// occupy. Any ANSI escape sequences in the string are taken as having no
+// width.
+func strWidth(s string) int {
+	w := 0
+
+	for i := 0; i < len(s); {
+		if n := escSeqLen(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+
+		r, n := utf8.DecodeRuneInString(s[i:])
+		w += runeWidth(r)
+		i += n
+	}
+
+	return w
+}
@@ -5,26 +5,58 @@ import (
 	"regexp"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
-// we break the string to be wrapped into paragraphs on either newlines or
-// form feeds
-var paraBreakRE = regexp.MustCompile("[\n\f]")
+// by default we break the string to be wrapped into paragraphs on either
+// newlines or form feeds
+const paraBreakChars = "\n\f"
+
+var paraBreakRE = regexp.MustCompile("[" + paraBreakChars + "]")
+
+// blankLineParaBreakRE splits text into paragraphs at blank lines or form
+// feeds
+var blankLineParaBreakRE = regexp.MustCompile(`\r?\n(?:[ \t\r]*\n)+|\f`)
+
+// paraBreak returns the regular expression used to split text into
+// paragraphs
+func (twc TWConf) paraBreak() *regexp.Regexp {
+	if twc.ParaBreak != nil {
+		return twc.ParaBreak
+	}
+
+	if twc.BlankLineParas {
+		return blankLineParaBreakRE
+	}
+
+	return paraBreakRE
+}
 
 // WrapPrefixed will print the text as with Wrap. The first line will start
 // with the prefix and the indent of the subsequent lines will be adjusted to
 // include the length of the prefix.
-func (twc TWConf) WrapPrefixed(prefix, text string, indent int) {
-	twc.Wrap3Indent(prefix+text,
-		indent, indent+len(prefix), indent+len(prefix))
+func (twc TWConf) WrapPrefixed(prefix, text string, indent int) (int, error) {
+	return twc.captureLimited(indent, func(twc TWConf) {
+		twc.wrapPrefixed(prefix, text, indent)
+	})
+}
+
+// wrapPrefixed performs the work of WrapPrefixed. It expects the writer to
+// record any errors.
+func (twc TWConf) wrapPrefixed(prefix, text string, indent int) {
+	twc.wrap3Indent(prefix+text,
+		indent, indent+strWidth(prefix), indent+strWidth(prefix))
 }
 
 // Wrap will print the text onto the configured writer but wraps and indents
 // the text. It will split the text into paragraphs at any newline
 // characters. It will always print at least MinCharsToPrint chars but will
 // try to fit the text into TargetLineLen chars.
-func (twc TWConf) Wrap(text string, indent int) {
-	twc.Wrap3Indent(text, indent, indent, indent)
+//
+// It returns the number of bytes written and any error encountered. Once
+// a write has failed nothing further is written.
+func (twc TWConf) Wrap(text string, indent int) (int, error) {
+	return twc.Wrap3Indent(text, indent, indent, indent)
 }
 
 // Wrap2Indent will print the text as with Wrap. The first line printed of
@@ -33,25 +65,47 @@ func (twc TWConf) Wrap(text string, indent int) {
 func (twc TWConf) Wrap2Indent(
 	text string,
 	firstLineIndent, otherLineIndent int,
-) {
-	twc.Wrap3Indent(text, firstLineIndent, firstLineIndent, otherLineIndent)
+) (int, error) {
+	return twc.Wrap3Indent(text,
+		firstLineIndent, firstLineIndent, otherLineIndent)
 }
 
-// calcMaxLen returns the max line length given the specified indent.
+// calcMaxLen returns the max line length given the specified indent. The
+// widths of any LineLeader and BreakMarker are also taken from the
+// TargetLineLen.
 func (twc TWConf) calcMaxLen(indent int) int {
 	return int(
 		math.Max(
 			float64(twc.MinCharsToPrint),
-			float64(twc.TargetLineLen-indent)))
+			float64(twc.TargetLineLen-
+				strWidth(twc.LineLeader)-
+				strWidth(twc.BreakMarker)-
+				indent)))
+}
+
+// lineStart returns the LineLeader followed by the indent
+func (twc TWConf) lineStart(indent int) string {
+	return twc.LineLeader + strings.Repeat(" ", indent)
 }
 
-// isAListItem returns true if the paragraph looks like an item in a
-// bulletted list - if the paragraph starts with one of '-', '*' or '+'
-// followed by a space
-func isAListItem(para string) bool {
-	return (strings.HasPrefix(para, "- ") ||
-		strings.HasPrefix(para, "* ") ||
-		strings.HasPrefix(para, "+ "))
+// blankLine returns a blank line. This is just the newline unless there is
+// a LineLeader in which case it is the leader without any trailing spaces.
+func (twc TWConf) blankLine() string {
+	return strings.TrimRight(twc.LineLeader, " \t") + "\n"
+}
+
+// appendSpace appends the space to the spaces. Any line or page breaks
+// (which may be within a paragraph if the paragraph break has been changed)
+// are replaced by a single space and carriage returns are dropped.
+func appendSpace(spaces []byte, r rune, s string) []byte {
+	switch r {
+	case '\r':
+		return spaces
+	case '\n', '\v', '\f', '\u0085', '\u2028', '\u2029':
+		return append(spaces, ' ')
+	}
+
+	return append(spaces, s...)
 }
 
 // isABreakableSpace returns true if the rune is a space and is not equal to
@@ -64,93 +118,581 @@ func isABreakableSpace(r rune) bool {
 // be indented by the first supplied indent, thereafter the first line of
 // each paragraph will be indented by the second supplied indent and any
 // other lines will be indented by the third indent value.
+//
+// If MaxLines is set then no more than that many lines are printed; if the
+// text would take more then the last line is replaced by the TruncMarker.
 func (twc TWConf) Wrap3Indent(
 	text string,
 	line1Indent, paraLine1Indent, line2Indent int,
+) (int, error) {
+	return twc.captureLimited(line1Indent, func(twc TWConf) {
+		twc.wrap3Indent(text, line1Indent, paraLine1Indent, line2Indent)
+	})
+}
+
+// wrap3Indent performs the work of Wrap3Indent. It expects the writer to
+// record any errors.
+func (twc TWConf) wrap3Indent(
+	text string,
+	line1Indent, paraLine1Indent, line2Indent int,
 ) {
 	if text == "" {
 		return
 	}
 
+	w := newWrapper(twc, line1Indent, paraLine1Indent, line2Indent)
+	w.wrapParas(text)
+}
+
+// wrapParas splits the text into paragraphs and wraps each one
+func (w *wrapper) wrapParas(text string) {
+	for _, para := range w.twc.paraBreak().Split(text, -1) {
+		w.addText(para)
+		w.endPara()
+	}
+}
+
+// wrapper holds the state needed to wrap text. The text of each paragraph
+// is passed to addText, possibly in several pieces, and then the paragraph
+// is finished by calling endPara. Each line is collected and then printed
+// once it is complete so that it can be aligned.
+type wrapper struct {
+	twc      TWConf
+	measurer *measurer // if set, this is told where the paragraphs end
+
+	line1Prefix     string // the prefix for the first line of the paragraph
+	paraLine1Prefix string // the prefix for the first line of later paras
+	line2Prefix     string // the prefix for the other lines
+
+	paraLine1MaxLen int
+	line2Indent     int
+	line2MaxLen     int
+
+	prefix     string // the prefix for the next line
+	lineLen    int    // the width of the text on the current line
+	maxLen     int    // the maximum width of the current line
+	nextMaxLen int    // the maximum width of the next line
+	style      sgrStyle
+
+	linePrefix string     // the prefix of the current line
+	lineStyle  string     // the style in effect at the start of the line
+	lineWords  []lineWord // the words on the current line
+	paraWords  []lineWord // the words of the paragraph, if breaking optimally
+	fixedGaps  int        // the leading gaps which must not be stretched
+
+	mode    paraMode // how the paragraph is to be printed
+	held    []byte   // the paragraph text held until the mode is known
+	inFence bool     // true while within a fenced verbatim block
+
+	paraPrinted bool // true once some of the paragraph has been printed
+	anyPrinted  bool // true once any paragraph has been printed
+
+	paraStarted bool   // true once some of the paragraph has been added
+	onLine1     bool   // true while on the first line of the paragraph
+	listChecked bool   // true once the paragraph is checked for a marker
+	paraHead    []byte // the start of the paragraph, until it is checked
+	word        []byte // the word being collected
+	spaces      []byte // the spaces preceding the word
+}
+
+// lineWord records a word on a line and the spaces preceding it together
+// with their widths
+type lineWord struct {
+	spaces    string
+	word      string
+	spacesLen int
+	wordLen   int
+
+	hyphenAt      []int // the offsets at which the word may be hyphenated
+	glued         bool  // the word is part of the preceding word
+	mustStartLine bool  // the word is the remainder of a word split earlier
+}
+
+// newWrapper creates a wrapper with the line prefixes and lengths
+// calculated from the indents
+func newWrapper(
+	twc TWConf,
+	line1Indent, paraLine1Indent, line2Indent int,
+) *wrapper {
 	// work out how much space we have between the indent and the end of line
-	var (
-		line1MaxLen     = twc.calcMaxLen(line1Indent)
-		paraLine1MaxLen = twc.calcMaxLen(paraLine1Indent)
-		line2MaxLen     = twc.calcMaxLen(line2Indent)
-	)
-
-	paras := paraBreakRE.Split(text, -1)
-	line1Prefix := strings.Repeat(" ", line1Indent)
-	line2Prefix := strings.Repeat(" ", line2Indent)
-	maxLen := line1MaxLen
-
-	for _, para := range paras {
-		if para != "" {
-			twc.Print(line1Prefix)
-		}
-
-		prefix := line2Prefix
-		secondLineMaxLen := line2MaxLen
-
-		if isAListItem(para) && line2MaxLen == maxLen {
-			listIndent := "  "
-			prefix += listIndent
-			secondLineMaxLen = twc.calcMaxLen(line2Indent + len(listIndent))
-		}
-
-		lineLen := 0
-		word := make([]rune, 0, len(para))
-		spaces := make([]rune, 0, maxLen)
-
-		for _, r := range para {
-			if isABreakableSpace(r) {
-				if len(word) > 0 {
-					lineLen, maxLen = twc.printWord(word, spaces,
-						prefix,
-						lineLen, maxLen, secondLineMaxLen)
-					word = word[:0]
-					spaces = spaces[:0]
-				}
-
-				spaces = append(spaces, r)
-			} else {
-				word = append(word, r)
+	w := &wrapper{
+		twc:             twc,
+		line1Prefix:     twc.lineStart(line1Indent),
+		paraLine1Prefix: twc.lineStart(paraLine1Indent),
+		line2Prefix:     twc.lineStart(line2Indent) + twc.ContMarker,
+		paraLine1MaxLen: twc.calcMaxLen(paraLine1Indent),
+		line2Indent:     line2Indent,
+		line2MaxLen:     twc.calcMaxLen(line2Indent + strWidth(twc.ContMarker)),
+		maxLen:          twc.calcMaxLen(line1Indent),
+	}
+	w.startPara()
+
+	return w
+}
+
+// startPara resets the wrapper ready for the start of a new paragraph
+func (w *wrapper) startPara() {
+	w.mode = paraUndecided
+	w.held = w.held[:0]
+	w.paraPrinted = false
+	w.paraStarted = false
+	w.listChecked = false
+	w.paraHead = w.paraHead[:0]
+	w.prefix = w.line2Prefix
+	w.nextMaxLen = w.line2MaxLen
+}
+
+// startLine starts a new line with the given prefix
+func (w *wrapper) startLine(prefix string) {
+	w.linePrefix = prefix
+	w.lineStyle = w.style.open()
+	w.lineWords = w.lineWords[:0]
+	w.lineLen = 0
+	w.fixedGaps = 0
+}
+
+// hasText returns true if any text has been added to the paragraph
+func (w *wrapper) hasText() bool {
+	return w.paraStarted || len(w.held) > 0
+}
+
+// addText adds the text to the current paragraph, printing any lines that
+// are complete. The text must not contain any paragraph breaks. Verbatim
+// paragraphs are held back until the end of the paragraph.
+func (w *wrapper) addText(text string) {
+	if text == "" {
+		return
+	}
+
+	if w.mode != paraWrapped {
+		w.held = append(w.held, text...)
+
+		if w.mode != paraUndecided || !w.decideMode(false) ||
+			w.mode != paraWrapped {
+			return
+		}
+
+		text = string(w.held)
+		w.held = w.held[:0]
+	}
+
+	w.wrapText(text)
+}
+
+// wrapText adds the text to the current paragraph which is to be wrapped
+func (w *wrapper) wrapText(text string) {
+	if !w.paraStarted {
+		w.startLine(w.line1Prefix)
+		w.paraStarted = true
+		w.onLine1 = true
+	}
+
+	if !w.listChecked {
+		w.paraHead = append(w.paraHead, text...)
+	}
+
+	for i := 0; i < len(text); {
+		if n := escSeqLen(text[i:]); n > 0 {
+			// escape sequences are kept with the word they precede
+			w.word = append(w.word, text[i:i+n]...)
+			i += n
+
+			continue
+		}
+
+		r, n := utf8.DecodeRuneInString(text[i:])
+		if isABreakableSpace(r) {
+			w.flushWord()
+			w.spaces = appendSpace(w.spaces, r, text[i:i+n])
+		} else {
+			w.word = append(w.word, text[i:i+n]...)
+		}
+
+		i += n
+	}
+}
+
+// flushWord adds any word that has been collected to the line
+func (w *wrapper) flushWord() {
+	if len(w.word) == 0 {
+		return
+	}
+
+	w.addWord(string(w.word), string(w.spaces))
+	w.word = w.word[:0]
+	w.spaces = w.spaces[:0]
+}
+
+// endPara prints any remaining text and ends the paragraph. The wrapper is
+// then ready for the next paragraph
+func (w *wrapper) endPara() {
+	if w.mode == paraUndecided {
+		w.decideMode(true)
+
+		if w.mode == paraWrapped && len(w.held) > 0 {
+			text := string(w.held)
+			w.held = w.held[:0]
+			w.wrapText(text)
+		}
+	}
+
+	switch w.mode {
+	case paraFence:
+		w.endFence()
+	case paraVerbatim:
+		w.printVerbatim()
+	default:
+		w.endWrappedPara()
+	}
+
+	if w.paraPrinted || !w.twc.BlankLineParas {
+		w.anyPrinted = true
+		w.line1Prefix = w.paraLine1Prefix
+		w.maxLen = w.paraLine1MaxLen
+	}
+
+	if w.measurer != nil {
+		w.measurer.endPara()
+	}
+
+	w.startPara()
+}
+
+// startOutput is called before anything is printed for the paragraph. If
+// paragraphs are separated by blank lines then the blank line is printed
+// before the first output of each paragraph but the first.
+func (w *wrapper) startOutput() {
+	if w.paraPrinted {
+		return
+	}
+
+	w.paraPrinted = true
+
+	if w.twc.BlankLineParas && w.anyPrinted {
+		w.twc.Print(w.twc.blankLine())
+
+		if w.measurer != nil {
+			w.measurer.startPara()
+		}
+	}
+}
+
+// endWrappedPara prints any remaining text of a wrapped paragraph
+func (w *wrapper) endWrappedPara() {
+	w.flushWord()
+	w.spaces = w.spaces[:0]
+	w.layoutPara()
+
+	switch {
+	case w.twc.BlankLineParas && !w.paraPrinted &&
+		(!w.paraStarted || len(w.lineWords) == 0):
+		// empty paragraphs are not printed
+	case w.paraStarted:
+		w.endLine(true)
+	default:
+		// this is a blank line unless the first line has a prefix other
+		// than the indent, such as the term printed by DefList
+		w.twc.Print(strings.TrimRight(w.line1Prefix, " \t") + "\n")
+	}
+}
+
+// setListIndent adds the width of the list marker to the prefix of the
+// following lines if the paragraph starts with a list marker and the
+// following lines would otherwise be the same length as the first line.
+// The gaps within and after the list marker are then fixed so that
+// justifying the first line doesn't move the text away from the following
+// lines. The marker is looked for in the text of the paragraph as it was
+// given rather than in the words on the first line since the space after
+// the marker belongs to the next word which may not be on the first line.
+func (w *wrapper) setListIndent() {
+	if w.listChecked {
+		return
+	}
+
+	w.listChecked = true
+	head := string(w.paraHead)
+	w.paraHead = w.paraHead[:0]
+
+	if w.twc.calcMaxLen(w.line2Indent) != w.maxLen {
+		return
+	}
+
+	n := w.twc.listMarkerLen(head)
+	if n == 0 {
+		return
+	}
+
+	w.fixedGaps = markerGaps(head, n)
+
+	markerWidth := strWidth(
+		w.twc.expandTabs(head[:n], strWidth(w.linePrefix)))
+	w.prefix += strings.Repeat(" ", markerWidth)
+	w.nextMaxLen = w.twc.calcMaxLen(
+		w.line2Indent + strWidth(w.twc.ContMarker) + markerWidth)
+}
+
+// markerGaps returns the number of gaps between the words of the text that
+// end within the first n bytes, that is the gaps within the list marker and
+// the gap after it
+func markerGaps(text string, n int) int {
+	gaps := 0
+	inWord, seenWord := false, false
+
+	for i, r := range text {
+		if i > n {
+			break
+		}
+
+		if isABreakableSpace(r) {
+			inWord = false
+			continue
+		}
+
+		if !inWord && seenWord {
+			gaps++
+		}
+
+		inWord, seenWord = true, true
+	}
+
+	return gaps
+}
+
+// addWord adds the word and any leading spaces to the current line. If the
+// word will not fit then it is hyphenated if possible, the line is printed
+// and the word (or its remainder) starts the next line. If the lines are to
+// be broken optimally the words are just collected until the end of the
+// paragraph.
+func (w *wrapper) addWord(word, spaces string) {
+	word, hyphenAt := w.twc.hyphenPoints(word)
+	lw := lineWord{
+		spaces:   spaces,
+		word:     word,
+		wordLen:  strWidth(word),
+		hyphenAt: hyphenAt,
+	}
+
+	if w.twc.BreakStrategy == BreakOptimal {
+		// the column isn't known until the lines are laid out so allow
+		// for the widest the spaces could be
+		lw.spacesLen = w.twc.maxSpacesWidth(spaces)
+		w.collectWord(lw)
+
+		return
+	}
+
+	lw.spaces = w.twc.expandTabs(spaces, w.column())
+	lw.spacesLen = strWidth(lw.spaces)
+
+	// always take the 1st word regardless of length (with leading spaces)
+	if len(w.lineWords) > 0 &&
+		w.lineLen+lw.spacesLen+lw.wordLen > w.maxLen {
+		room := w.maxLen - w.lineLen - lw.spacesLen
+		if head, tail, ok := hyphenate(lw, room); ok {
+			w.appendWord(head)
+			lw = tail
+		}
+
+		w.breakLine()
+	}
+
+	for len(w.lineWords) == 0 {
+		room := w.maxLen
+		if w.onLine1 {
+			room -= lw.spacesLen
+		}
+
+		if lw.wordLen <= room {
+			break
+		}
+
+		head, tail, ok := hyphenate(lw, room)
+		if !ok {
+			if w.twc.Overflow == OverflowAllow {
+				break
 			}
+
+			head, tail = w.twc.splitWord(lw, room)
 		}
 
-		if len(word) > 0 {
-			twc.printWord(word, spaces,
-				prefix,
-				lineLen, maxLen, secondLineMaxLen)
+		w.appendWord(head)
+
+		if tail.word == "" {
+			return
 		}
 
-		twc.Println()
+		w.breakLine()
 
-		line1Prefix = strings.Repeat(" ", paraLine1Indent)
-		maxLen = paraLine1MaxLen
+		lw = tail
 	}
+
+	w.appendWord(lw)
 }
 
-// printWord prints the word and any leading spaces and returns the new line
-// length and the new max length
-func (twc TWConf) printWord(word, spaces []rune, prefix string,
-	lineLen, maxLen, nextMaxLen int,
-) (int, int) {
-	if lineLen == 0 {
-		// always print 1st word regardless of length (with leading spaces)
-		twc.Print(string(spaces) + string(word))
-		return len(spaces) + len(word), maxLen
+// collectWord adds the word to the words of the paragraph to be laid out
+// later. The word is split at each hyphenation point.
+func (w *wrapper) collectWord(lw lineWord) {
+	w.paraWords = append(w.paraWords, splitAtHyphens(lw)...)
+}
+
+// splitOverflows splits any of the words of the paragraph too long to fit
+// on a line by themselves according to the overflow policy. This must be
+// done after any list indent is known as it changes the space available.
+func (w *wrapper) splitOverflows() {
+	if w.twc.Overflow == OverflowAllow {
+		return
 	}
 
-	if lineLen+len(word)+len(spaces) <= maxLen { // word & space fit in the line
-		lineLen += len(word) + len(spaces)
-		twc.Print(string(spaces) + string(word))
+	words := make([]lineWord, 0, len(w.paraWords))
+
+	for _, part := range w.paraWords {
+		room := w.nextMaxLen
+		if len(words) == 0 {
+			room = w.maxLen - part.spacesLen
+		}
+
+		for part.word != "" && part.wordLen > room {
+			head, tail := w.twc.splitWord(part, room)
+			words = append(words, head)
 
-		return lineLen, maxLen
+			part = tail
+			room = w.nextMaxLen
+		}
+
+		if part.word != "" {
+			words = append(words, part)
+		}
+	}
+
+	w.paraWords = words
+}
+
+// breakLine prints the current line and starts the next one
+func (w *wrapper) breakLine() {
+	if w.onLine1 {
+		w.setListIndent()
+	}
+
+	w.endLine(false)
+	w.maxLen = w.nextMaxLen
+	w.onLine1 = false
+	w.startLine(w.prefix)
+}
+
+// column returns the column at which the next text on the line will start
+func (w *wrapper) column() int {
+	return strWidth(w.linePrefix) + w.lineLen
+}
+
+// appendWord appends the word to the current line. Any leading spaces are
+// discarded if the word starts a line other than the first line of the
+// paragraph, otherwise any tabs they contain are expanded.
+func (w *wrapper) appendWord(lw lineWord) {
+	if len(w.lineWords) == 0 && !w.onLine1 {
+		lw.spaces, lw.spacesLen = "", 0
+	} else if strings.ContainsRune(lw.spaces, '\t') {
+		lw.spaces = w.twc.expandTabs(lw.spaces, w.column())
+		lw.spacesLen = strWidth(lw.spaces)
 	}
 
-	twc.Println()
-	twc.Print(prefix + string(word))
+	w.lineWords = append(w.lineWords, lw)
+	w.lineLen += lw.spacesLen + lw.wordLen
+	w.style.update(lw.word)
+}
+
+// appendToLastWord adds the text to the end of the last word on the line
+func (w *wrapper) appendToLastWord(text string) {
+	last := &w.lineWords[len(w.lineWords)-1]
+	textLen := strWidth(text)
+
+	last.word += text
+	last.wordLen += textLen
+	w.lineLen += textLen
+	w.style.update(text)
+}
+
+// layoutPara lays out the words collected for the paragraph breaking the
+// lines so as to minimise the raggedness of the paragraph
+func (w *wrapper) layoutPara() {
+	if len(w.paraWords) == 0 {
+		return
+	}
+
+	w.setListIndent()
+	w.splitOverflows()
+	starts := optimalBreaks(w.paraWords, w.maxLen, w.nextMaxLen)
+
+	for i, lw := range w.paraWords {
+		switch {
+		case len(starts) > 0 && starts[0] == i:
+			starts = starts[1:]
+
+			if lw.glued {
+				w.appendToLastWord(hyphen)
+			}
+
+			w.breakLine()
+			w.appendWord(lw)
+		case lw.glued:
+			w.appendToLastWord(lw.word)
+		default:
+			w.appendWord(lw)
+		}
+	}
+
+	w.paraWords = w.paraWords[:0]
+}
+
+// endLine prints the current line, aligned according to the TWConf. Any
+// style in effect is restored after the prefix and closed before the
+// newline so that it doesn't extend into the indent.
+func (w *wrapper) endLine(lastLine bool) {
+	w.startOutput()
+
+	var b strings.Builder
+
+	b.WriteString(w.linePrefix)
+
+	pad := max(w.maxLen-w.lineLen, 0)
+	if len(w.lineWords) == 0 {
+		pad = 0
+	}
+
+	switch w.twc.Align {
+	case AlignRight:
+		b.WriteString(strings.Repeat(" ", pad))
+	case AlignCentre:
+		b.WriteString(strings.Repeat(" ", pad/2))
+	}
+
+	b.WriteString(w.lineStyle)
+
+	justify := w.twc.Align == AlignJustify && !lastLine
+	gaps := len(w.lineWords) - 1 - w.fixedGaps
+
+	for i, lw := range w.lineWords {
+		b.WriteString(lw.spaces)
+
+		if gap := i - w.fixedGaps; justify && gap > 0 {
+			extra := pad / gaps
+			if gap <= pad%gaps {
+				extra++
+			}
+
+			b.WriteString(strings.Repeat(" ", extra))
+		}
+
+		b.WriteString(lw.word)
+	}
+
+	if w.style.isSet() {
+		b.WriteString(sgrReset)
+	}
+
+	if !lastLine {
+		b.WriteString(w.twc.BreakMarker)
+	}
 
-	return len(word), nextMaxLen
+	b.WriteString("\n")
+	w.twc.Print(b.String())
 }
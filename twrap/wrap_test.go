@@ -2,6 +2,7 @@ package twrap_test
 
 import (
 	"bytes"
+	"errors"
 	"testing"
 
 	"github.com/nickwells/testhelper.mod/v2/testhelper"
@@ -70,6 +71,27 @@ func TestWrap(t *testing.T) {
 bbb ccc
 `,
 		},
+		{
+			ID:   testhelper.MkID("wide chars"),
+			text: "日本語の 文章です 折り返し",
+			expText: `日本語の 文章です
+折り返し
+`,
+		},
+		{
+			ID:     testhelper.MkID("wide chars - indent = 5"),
+			indent: 5,
+			text:   "中文 中文 中文 中文 中文",
+			expText: `     中文 中文 中文
+     中文 中文
+`,
+		},
+		{
+			ID:   testhelper.MkID("combining marks"),
+			text: "cafe\u0301 cafe\u0301 cafe\u0301 cafe\u0301 cafe\u0301",
+			expText: "cafe\u0301 cafe\u0301 cafe\u0301 cafe\u0301\n" +
+				"cafe\u0301\n",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -230,3 +252,126 @@ func TestWrap3Indent(t *testing.T) {
 			buf.String(), tc.expText)
 	}
 }
+
+func TestWrapANSI(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		indent  int
+		text    string
+		expText string
+	}{
+		{
+			ID:      testhelper.MkID("escapes take no space"),
+			text:    "\x1b[1maaa\x1b[0m \x1b[4mbbb\x1b[0m ccc ddd eee",
+			expText: "\x1b[1maaa\x1b[0m \x1b[4mbbb\x1b[0m ccc ddd eee\n",
+		},
+		{
+			ID:     testhelper.MkID("style spanning a line break"),
+			indent: 2,
+			text:   "\x1b[1;31maaa bbb ccc ddd eee fff\x1b[0m ggg",
+			expText: "  \x1b[1;31maaa bbb ccc ddd\x1b[0m\n" +
+				"  \x1b[1;31meee fff\x1b[0m ggg\n",
+		},
+		{
+			ID:     testhelper.MkID("style spanning a paragraph break"),
+			indent: 2,
+			text:   "aaa \x1b[32mbbb\nccc\x1b[m ddd",
+			expText: "  aaa \x1b[32mbbb\x1b[0m\n" +
+				"  \x1b[32mccc\x1b[m ddd\n",
+		},
+		{
+			ID:     testhelper.MkID("OSC sequence with spaces"),
+			indent: 2,
+			text:   "\x1b]0;a window title\aaaa bbb ccc ddd eee fff",
+			expText: "  \x1b]0;a window title\aaaa bbb ccc ddd\n" +
+				"  eee fff\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		var buf bytes.Buffer
+
+		twc, err := twrap.NewTWConf(
+			twrap.SetWriter(&buf),
+			twrap.SetMinChars(10),
+			twrap.SetTargetLineLen(20))
+		if err != nil {
+			t.Fatal(tc.IDStr(), ": Couldn't create the TWConf: ", err)
+		}
+
+		twc.Wrap(tc.text, tc.indent)
+		testhelper.DiffString(t, tc.IDStr(), "wrapped text",
+			buf.String(), tc.expText)
+	}
+}
+
+// limitedWriter accepts writes until the limit is reached after which it
+// returns an error
+type limitedWriter struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+// Write writes to the buffer until the limit is reached
+func (lw *limitedWriter) Write(b []byte) (int, error) {
+	if lw.buf.Len()+len(b) > lw.limit {
+		n := lw.limit - lw.buf.Len()
+		lw.buf.Write(b[:n])
+
+		return n, errors.New("write limit reached")
+	}
+
+	return lw.buf.Write(b)
+}
+
+func TestWrapErr(t *testing.T) {
+	const text = "123 567 901 345 789 123 567 901"
+
+	testCases := []struct {
+		testhelper.ID
+		testhelper.ExpErr
+		limit   int
+		expText string
+	}{
+		{
+			ID:      testhelper.MkID("no error"),
+			limit:   100,
+			expText: "123 567 901 345 789\n123 567 901\n",
+		},
+		{
+			ID:      testhelper.MkID("error mid-line"),
+			ExpErr:  testhelper.MkExpErr("write limit reached"),
+			limit:   25,
+			expText: "123 567 901 345 789\n123 5",
+		},
+		{
+			ID:      testhelper.MkID("error at once"),
+			ExpErr:  testhelper.MkExpErr("write limit reached"),
+			limit:   0,
+			expText: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		lw := &limitedWriter{limit: tc.limit}
+
+		twc := twrap.NewTWConfOrPanic(
+			twrap.SetWriter(lw),
+			twrap.SetMinChars(10),
+			twrap.SetTargetLineLen(20))
+
+		n, err := twc.Wrap(text, 0)
+		testhelper.CheckExpErr(t, err, tc)
+		testhelper.DiffInt(t, tc.IDStr(), "bytes written", n, len(tc.expText))
+		testhelper.DiffString(t, tc.IDStr(), "wrapped text",
+			lw.buf.String(), tc.expText)
+
+		lw = &limitedWriter{limit: tc.limit}
+		twc.W = lw
+
+		n, err = twc.ListItem(0, "123 567 901 345 789", "123 567 901")
+		testhelper.CheckExpErr(t, err, tc)
+		testhelper.DiffInt(t, tc.IDStr(), "list bytes written",
+			n, lw.buf.Len())
+	}
+}
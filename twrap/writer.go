@@ -0,0 +1,251 @@
+package twrap
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const maxWriterIndents = 3
+
+var errWriterClosed = errors.New("the twrap.Writer has been closed")
+
+// Writer is an io.WriteCloser which wraps the text written to it according
+// to the settings of the TWConf it was created with. It applies the same
+// rules as Wrap3Indent but text is only held back until a line is complete
+// so arbitrarily long text can be passed through it. Note that if the
+// TWConf's BreakStrategy is BreakOptimal then each paragraph is held back
+// until it is complete.
+//
+// A newline or form feed ends the paragraph being written. When the Writer
+// is closed any unfinished paragraph is printed and ended with a newline.
+// If the TWConf has a ParaBreak set, or BlankLineParas is set, then the
+// text is held back until the end of each paragraph is found, as a later
+// write could change the way the text is split. A paragraph break is only
+// taken as complete once it is followed by text other than white space
+// (or the Writer is closed). With a ParaBreak set the text since the last
+// paragraph break is searched again on each write.
+//
+// If the TWConf has MaxLines set then no more than that many lines are
+// printed in total; the last line is only printed, or replaced by the
+// TruncMarker, when the Writer is closed.
+type Writer struct {
+	ew      *errWriter
+	ll      *lineLimiter // set if the TWConf has MaxLines set
+	indent  int          // the indent of the first line, for the TruncMarker
+	w       *wrapper
+	pending []byte // an incomplete rune or escape sequence
+	unsplit []byte // text not yet split into paragraphs
+	scanned int    // the offset in unsplit up to which it has been searched
+	closed  bool
+}
+
+// NewWriter returns a Writer which will wrap the text written to it and
+// print it to the TWConf's writer. The indents are interpreted as follows:
+//
+//   - none: no text is indented
+//   - one: all the lines are indented by this amount
+//   - two: as for Wrap2Indent, the first lines of the paragraphs are
+//     indented by the first value and the others by the second
+//   - three: as for Wrap3Indent
+//
+// It will panic if more than three indents are given.
+func NewWriter(twc TWConf, indents ...int) *Writer {
+	var line1Indent, paraLine1Indent, line2Indent int
+
+	switch len(indents) {
+	case 0:
+	case 1:
+		line1Indent = indents[0]
+		paraLine1Indent = indents[0]
+		line2Indent = indents[0]
+	case 2:
+		line1Indent = indents[0]
+		paraLine1Indent = indents[0]
+		line2Indent = indents[1]
+	case 3:
+		line1Indent = indents[0]
+		paraLine1Indent = indents[1]
+		line2Indent = indents[2]
+	default:
+		panic(fmt.Errorf("too many indents (%d) passed to NewWriter,"+
+			" at most %d are allowed",
+			len(indents), maxWriterIndents))
+	}
+
+	tw := &Writer{
+		ew:     &errWriter{w: twc.W},
+		indent: line1Indent,
+	}
+	twc.W = tw.ew
+
+	if twc.MaxLines > 0 {
+		tw.ll = &lineLimiter{w: tw.ew, max: twc.MaxLines}
+		twc.W = tw.ll
+	}
+
+	tw.w = newWrapper(twc, line1Indent, paraLine1Indent, line2Indent)
+
+	return tw
+}
+
+// Write adds the text to the text to be wrapped. Any complete words are
+// printed. It returns any error from writing to the underlying writer;
+// once an error has been seen nothing further is printed.
+func (tw *Writer) Write(p []byte) (int, error) {
+	if tw.closed {
+		return 0, errWriterClosed
+	}
+
+	if tw.ew.err != nil {
+		return 0, tw.ew.err
+	}
+
+	tw.pending = append(tw.pending, p...)
+	n := len(tw.pending) - incompleteTail(tw.pending)
+	tw.write(string(tw.pending[:n]), false)
+	tw.pending = tw.pending[:copy(tw.pending, tw.pending[n:])]
+
+	return len(p), tw.ew.err
+}
+
+// Close prints any remaining text, ending any unfinished paragraph. It
+// returns the first error seen while writing to the underlying writer.
+// Writing to a closed Writer is an error.
+func (tw *Writer) Close() error {
+	if tw.closed {
+		return errWriterClosed
+	}
+
+	tw.closed = true
+
+	tw.write(string(tw.pending), true)
+	tw.pending = nil
+
+	if tw.w.hasText() {
+		tw.w.endPara()
+	}
+
+	if tw.ll != nil {
+		tw.ll.finish(tw.w.twc, tw.indent)
+	}
+
+	return tw.ew.err
+}
+
+// write passes the text to the wrapper, ending the paragraph at each
+// paragraph break. The final flag is set if no more text will be written.
+func (tw *Writer) write(text string, final bool) {
+	if re := tw.w.twc.paraBreak(); re != paraBreakRE {
+		tw.writeSplitByRE(re, text, final)
+		return
+	}
+
+	for {
+		i := strings.IndexAny(text, paraBreakChars)
+		if i < 0 {
+			tw.w.addText(text)
+			return
+		}
+
+		tw.w.addText(text[:i])
+		tw.w.endPara()
+		text = text[i+1:]
+	}
+}
+
+// writeSplitByRE adds the text to any text not yet split into paragraphs
+// and passes each complete paragraph to the wrapper. A paragraph break
+// might be extended by the next write and so it is only taken as complete
+// if it is followed by some text other than white space or if no more
+// text will be written; any text after the last complete paragraph break
+// is passed to the wrapper only at that point.
+//
+// Any paragraph break may start anywhere in the unsplit text and so it is
+// all searched again. The exception is the blank line paragraph break
+// which only matches white space; the search for that need only go back
+// to the start of any white space at the end of the text already searched
+// so a long paragraph written in small pieces is not searched again and
+// again.
+func (tw *Writer) writeSplitByRE(re *regexp.Regexp, text string, final bool) {
+	tw.unsplit = append(tw.unsplit, text...)
+	start := 0
+
+	from := 0
+	if re == blankLineParaBreakRE {
+		from = tw.scanned
+		for from > 0 && isASCIISpace(tw.unsplit[from-1]) {
+			from--
+		}
+	}
+
+	for _, loc := range re.FindAllIndex(tw.unsplit[from:], -1) {
+		if loc[0] == loc[1] {
+			continue
+		}
+
+		brkStart, brkEnd := from+loc[0], from+loc[1]
+
+		if !final && !hasNonSpace(tw.unsplit[brkEnd:]) {
+			break
+		}
+
+		tw.w.addText(string(tw.unsplit[start:brkStart]))
+		tw.w.endPara()
+		start = brkEnd
+	}
+
+	if final {
+		tw.w.addText(string(tw.unsplit[start:]))
+		start = len(tw.unsplit)
+	}
+
+	tw.unsplit = tw.unsplit[:copy(tw.unsplit, tw.unsplit[start:])]
+	tw.scanned = len(tw.unsplit)
+}
+
+// hasNonSpace returns true if b holds any byte other than ASCII white space
+func hasNonSpace(b []byte) bool {
+	for _, c := range b {
+		if !isASCIISpace(c) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isASCIISpace returns true if the byte is an ASCII white space character
+func isASCIISpace(b byte) bool {
+	switch b {
+	case ' ', '\t', '\n', '\v', '\f', '\r':
+		return true
+	}
+
+	return false
+}
+
+// incompleteTail returns the number of bytes at the end of b which form an
+// incomplete rune or escape sequence. These should be held back until the
+// rest of the rune or sequence arrives.
+func incompleteTail(b []byte) int {
+	if i := bytes.LastIndexByte(b, esc); i >= 0 &&
+		isPartialEscSeq(string(b[i:])) {
+		return len(b) - i
+	}
+
+	for n := 1; n < utf8.UTFMax && n <= len(b); n++ {
+		if utf8.RuneStart(b[len(b)-n]) {
+			if utf8.FullRune(b[len(b)-n:]) {
+				return 0
+			}
+
+			return n
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,151 @@
+package twrap_test
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/nickwells/testhelper.mod/v2/testhelper"
+	"github.com/nickwells/twrap.mod/twrap"
+)
+
+func TestWriter(t *testing.T) {
+	testCases := []struct {
+		testhelper.ID
+		text string
+	}{
+		{
+			ID:   testhelper.MkID("simple"),
+			text: "123 567 901 345 789 123 567 901",
+		},
+		{
+			ID: testhelper.MkID("paragraphs and list items"),
+			text: "aaa bbb ccc ddd eee fff ggg\n\n" +
+				"- hhh iii jjj kkk lll mmm nnn ooo\f" +
+				"   ppp    qqq rrr sss ttt uuu",
+		},
+		{
+			ID:   testhelper.MkID("wide chars"),
+			text: "日本語の 文章です 折り返し 日本語の 文章です",
+		},
+		{
+			ID: testhelper.MkID("escape sequences"),
+			text: "\x1b[1;31maaa bbb ccc ddd eee fff\x1b[0m ggg" +
+				" \x1b]0;a window title\ahhh iii",
+		},
+	}
+
+	for _, tc := range testCases {
+		var expBuf bytes.Buffer
+
+		twc := twrap.NewTWConfOrPanic(
+			twrap.SetWriter(&expBuf),
+			twrap.SetMinChars(10),
+			twrap.SetTargetLineLen(20))
+		twc.Wrap3Indent(tc.text, 2, 3, 4)
+
+		for _, chunkSize := range []int{1, 2, 3, 7, len(tc.text)} {
+			var buf bytes.Buffer
+
+			twc.W = &buf
+			w := twrap.NewWriter(*twc, 2, 3, 4)
+
+			for text := tc.text; text != ""; {
+				n := min(chunkSize, len(text))
+				if _, err := w.Write([]byte(text[:n])); err != nil {
+					t.Fatal(tc.IDStr(), ": unexpected Write error: ", err)
+				}
+
+				text = text[n:]
+			}
+
+			if err := w.Close(); err != nil {
+				t.Fatal(tc.IDStr(), ": unexpected Close error: ", err)
+			}
+
+			testhelper.DiffString(t, tc.IDStr(), "wrapped text",
+				buf.String(), expBuf.String())
+		}
+	}
+}
+
+func TestWriterClosed(t *testing.T) {
+	var buf bytes.Buffer
+
+	twc := twrap.NewTWConfOrPanic(twrap.SetWriter(&buf))
+	w := twrap.NewWriter(*twc)
+
+	if _, err := w.Write([]byte("text\n")); err != nil {
+		t.Fatal("unexpected Write error: ", err)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal("unexpected Close error: ", err)
+	}
+
+	testhelper.DiffString(t, "closed Writer", "wrapped text",
+		buf.String(), "text\n")
+
+	_, err := w.Write([]byte("more text"))
+	testhelper.CheckError(t, "write after close", err,
+		true, []string{"closed"})
+}
+
+func TestWriterParaBreakRE(t *testing.T) {
+	spaces := strings.Repeat(" ", 100)
+
+	testCases := []struct {
+		testhelper.ID
+		re   *regexp.Regexp
+		text string
+	}{
+		{
+			ID: testhelper.MkID("long runs of spaces"),
+			re: regexp.MustCompile(`\n[ \t]*\n\s*`),
+			text: "the first paragraph\nhas two lines\n" + spaces + "\n" +
+				"the second paragraph " + strings.Repeat("is long ", 40) +
+				"\n\n\n" + spaces + "and the third" + spaces + "\n" +
+				spaces + "\n\nthe last",
+		},
+		{
+			ID: testhelper.MkID("long break"),
+			re: regexp.MustCompile("\n-{3,}\n"),
+			text: "first para\ntext\n" + strings.Repeat("-", 100) +
+				"\nsecond para",
+		},
+		{
+			ID:   testhelper.MkID("greedy break"),
+			re:   regexp.MustCompile(`\n\s*\n`),
+			text: "one two\n\n \n  end",
+		},
+	}
+
+	for _, tc := range testCases {
+		twc := twrap.NewTWConfOrPanic(
+			twrap.SetMinChars(10),
+			twrap.SetTargetLineLen(150),
+			twrap.SetParaBreak(tc.re))
+		exp := twc.Wrap3IndentString(tc.text, 2, 3, 4)
+
+		for _, chunkSize := range []int{1, 5, 27, len(tc.text)} {
+			var buf bytes.Buffer
+
+			twc.W = &buf
+			w := twrap.NewWriter(*twc, 2, 3, 4)
+
+			for text := tc.text; text != ""; {
+				n := min(chunkSize, len(text))
+				_, _ = w.Write([]byte(text[:n]))
+				text = text[n:]
+			}
+
+			_ = w.Close()
+
+			testhelper.DiffString(t,
+				fmt.Sprintf("%s: chunk size: %d", tc.IDStr(), chunkSize),
+				"wrapped text", buf.String(), exp)
+		}
+	}
+}